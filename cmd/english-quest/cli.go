@@ -0,0 +1,538 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/archive"
+	"tui-english-quest/internal/cards"
+	"tui-english-quest/internal/config"
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/services"
+	"tui-english-quest/internal/ui/components"
+)
+
+// errUsage marks a command line that could not be parsed; usage has already
+// been printed.
+var errUsage = errors.New("usage")
+
+// cliEnv is what every subcommand runs against: the active profile and the
+// terminal streams.
+type cliEnv struct {
+	cfg   config.Config
+	stats game.Stats
+	in    io.Reader
+	out   io.Writer
+	err   io.Writer
+}
+
+type cliCommand struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *cliEnv, args []string) error
+}
+
+var cliCommands = []cliCommand{
+	{"stats", "Show the active profile's stats", runStats},
+	{"history", "List recent sessions", runHistory},
+	{"export", "Write the profile and all its progress to a JSON archive", runExport},
+	{"import", "Restore a profile from an archive", runImport},
+	{"cards", "Export answered vocab and spelling items as CSV or Anki flashcards", runCards},
+	{"analyze", "Print the weakness analysis", runAnalyze},
+	{"play", "Play a quick drill in plain text", runPlay},
+}
+
+func findCLICommand(name string) (cliCommand, bool) {
+	for _, c := range cliCommands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return cliCommand{}, false
+}
+
+// runCLI runs the subcommand in args and returns the process exit code.
+func runCLI(ctx context.Context, env *cliEnv, args []string) int {
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printCLIUsage(env.out)
+		return 0
+	}
+	cmd, ok := findCLICommand(args[0])
+	if !ok {
+		fmt.Fprintf(env.err, "english-quest: unknown command %q\n\n", args[0])
+		printCLIUsage(env.err)
+		return 2
+	}
+	if err := cmd.run(ctx, env, args[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(env.err, "english-quest %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+func printCLIUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: english-quest [command] [flags]")
+	fmt.Fprintln(w, "\nWithout a command the game starts in the terminal UI.\n\nCommands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, c := range cliCommands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\nRun 'english-quest <command> -h' for the command's flags.")
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting.
+func newFlagSet(env *cliEnv, name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.err)
+	fs.Usage = func() {
+		fmt.Fprintf(env.err, "Usage: english-quest %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args, turning a parse failure into errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func runStats(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "stats", "[-json]")
+	asJSON := fs.Bool("json", false, "print the stats as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	s := env.stats
+	if *asJSON {
+		return writeJSON(env.out, s)
+	}
+	tier, _ := game.TierForLevel(s.Level)
+	tw := tabwriter.NewWriter(env.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name\t%s\n", s.Name)
+	fmt.Fprintf(tw, "Class\t%s\n", s.Class)
+	fmt.Fprintf(tw, "Level\t%d (tier %d)\n", s.Level, tier)
+	fmt.Fprintf(tw, "EXP\t%d/%d\n", s.Exp, s.Next)
+	fmt.Fprintf(tw, "HP\t%d/%d\n", s.HP, s.MaxHP)
+	fmt.Fprintf(tw, "Gold\t%d\n", s.Gold)
+	fmt.Fprintf(tw, "Combo\t%d\n", s.Combo)
+	fmt.Fprintf(tw, "Streak\t%d days\n", s.Streak)
+	return tw.Flush()
+}
+
+func runHistory(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "history", "[-limit N] [-mode MODE] [-from YYYY-MM-DD] [-to YYYY-MM-DD] [-json]")
+	limit := fs.Int("limit", 20, "number of sessions to list")
+	mode := fs.String("mode", "", "only list sessions of MODE (vocab, grammar, tavern, spelling, listening or boss)")
+	from := fs.String("from", "", "only list sessions that ended on or after this day")
+	to := fs.String("to", "", "only list sessions that ended on or before this day")
+	asJSON := fs.Bool("json", false, "print the sessions as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	f, err := historyFilter(*mode, *from, *to)
+	if err != nil {
+		return err
+	}
+	sessions, err := db.ListSessionsPage(ctx, env.cfg.ProfileID, f, *limit, 0)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if sessions == nil {
+			sessions = []db.SessionRecord{}
+		}
+		return writeJSON(env.out, sessions)
+	}
+	if len(sessions) == 0 {
+		fmt.Fprintln(env.out, "No sessions yet.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(env.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ENDED\tMODE\tCORRECT\tEXP\tHP\tGOLD\tCOMBO\tNOTES")
+	for _, s := range sessions {
+		var notes []string
+		if s.Review {
+			notes = append(notes, "review")
+		}
+		if s.LeveledUp {
+			notes = append(notes, "level up")
+		}
+		if s.Fainted {
+			notes = append(notes, "fainted")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%+d\t%+d\t%+d\t%d\t%s\n",
+			s.EndedAt.Local().Format("2006-01-02 15:04"), s.Mode, sessionScore(s),
+			s.ExpGained, s.HPDelta, s.GoldDelta, s.BestCombo, strings.Join(notes, ", "))
+	}
+	return tw.Flush()
+}
+
+// historyModes are the values history -mode accepts.
+var historyModes = []string{services.ModeVocab, services.ModeGrammar, services.ModeTavern, services.ModeSpelling, services.ModeListening, "boss"}
+
+// historyFilter builds the session filter for history's -mode, -from and -to
+// flags. Days are local calendar days and both ends are inclusive.
+func historyFilter(mode, from, to string) (db.SessionFilter, error) {
+	f := db.SessionFilter{Mode: mode}
+	if mode != "" && !slices.Contains(historyModes, mode) {
+		return f, fmt.Errorf("unknown mode %q (want %s)", mode, strings.Join(historyModes, ", "))
+	}
+	if from != "" {
+		day, err := time.ParseInLocation(time.DateOnly, from, time.Local)
+		if err != nil {
+			return f, fmt.Errorf("invalid -from %q (want YYYY-MM-DD)", from)
+		}
+		f.Since = day
+	}
+	if to != "" {
+		day, err := time.ParseInLocation(time.DateOnly, to, time.Local)
+		if err != nil {
+			return f, fmt.Errorf("invalid -to %q (want YYYY-MM-DD)", to)
+		}
+		f.Until = day.AddDate(0, 0, 1)
+	}
+	if !f.Since.IsZero() && !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return f, fmt.Errorf("-from %s is after -to %s", from, to)
+	}
+	return f, nil
+}
+
+// sessionScore shows the correct answers out of the questions logged for s,
+// or just the correct answers for sessions saved without a log.
+func sessionScore(s db.SessionRecord) string {
+	if s.QuestionCount == 0 {
+		return strconv.Itoa(s.CorrectCount)
+	}
+	return fmt.Sprintf("%d/%d", s.CorrectCount, s.QuestionCount)
+}
+
+func runExport(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "export", "[-o FILE]")
+	outPath := fs.String("o", "", "write to FILE instead of standard output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *outPath == "" {
+		return archive.Export(ctx, env.out, env.cfg.ProfileID)
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		return err
+	}
+	if err := archive.Export(ctx, f, env.cfg.ProfileID); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.out, "Exported %s to %s\n", env.stats.Name, *outPath)
+	return nil
+}
+
+// runImport restores an archive written by export, on this or another
+// machine.
+func runImport(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "import", "[-on-conflict fail|merge|replace] [-switch] FILE")
+	onConflict := fs.String("on-conflict", "fail", "what to do when the profile already exists: fail, merge or replace")
+	switchTo := fs.Bool("switch", false, "make the imported profile the active one")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	conflict, err := archive.ParseConflict(*onConflict)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	res, err := archive.Import(ctx, f, conflict)
+	if errors.Is(err, archive.ErrProfileExists) {
+		return fmt.Errorf("%w; rerun with -on-conflict merge or replace", err)
+	}
+	if err != nil {
+		return err
+	}
+	verb := "Imported"
+	if res.Merged {
+		verb = "Merged"
+	}
+	fmt.Fprintf(env.out, "%s %s (level %d) with %d new sessions\n", verb, res.Name, res.Level, res.Sessions)
+	if *switchTo && res.ProfileID != env.cfg.ProfileID {
+		env.cfg = env.cfg.WithProfile(res.ProfileID)
+		if err := config.SaveConfig(env.cfg); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.out, "%s is now the active profile\n", res.Name)
+	}
+	return nil
+}
+
+func runCards(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "cards", "[-format csv|anki] [-mode vocab|spelling|all] [-status missed|learned|all] [-o FILE]")
+	format := fs.String("format", "csv", "csv, or anki for tab-separated text Anki can import")
+	mode := fs.String("mode", "all", "vocab, spelling or all")
+	status := fs.String("status", "all", "missed, learned or all; taken from the latest attempt")
+	outPath := fs.String("o", "", "write to FILE instead of standard output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	write := cards.WriteCSV
+	switch *format {
+	case "csv":
+	case "anki":
+		write = cards.WriteAnki
+	default:
+		return fmt.Errorf("unknown format %q (want csv or anki)", *format)
+	}
+	filter, err := cards.ParseFilter(*mode, *status)
+	if err != nil {
+		return err
+	}
+	list, err := cards.Load(ctx, env.cfg.ProfileID, filter)
+	if err != nil {
+		return err
+	}
+	if *outPath == "" {
+		return write(env.out, list)
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		return err
+	}
+	if err := write(f, list); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.out, "Wrote %d cards to %s\n", len(list), *outPath)
+	return nil
+}
+
+func runAnalyze(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "analyze", "[-limit N]")
+	limit := fs.Int("limit", 200, "number of recent sessions to analyze")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	p, err := services.NewProvider(ctx)
+	if err != nil {
+		return err
+	}
+	report, err := services.AnalyzeWeakness(ctx, p, env.cfg.ProfileID, env.stats, *limit)
+	if err != nil {
+		return err
+	}
+	if err := services.RecordAnalysis(ctx, env.cfg.ProfileID, report); err != nil {
+		return err
+	}
+	fmt.Fprintln(env.out, report.Summary)
+	printInsights := func(title string, insights []services.ModeInsight) {
+		if len(insights) == 0 {
+			return
+		}
+		fmt.Fprintf(env.out, "\n%s:\n", title)
+		for _, in := range insights {
+			fmt.Fprintf(env.out, "  - %s: %.0f%% over %d sessions\n", in.Mode, in.Accuracy*100, in.Sessions)
+		}
+	}
+	printInsights("Weak points", report.WeakPoints)
+	printInsights("Strengths", report.StrengthPoints)
+	if report.Recommendation != "" {
+		fmt.Fprintf(env.out, "\nRecommendation: %s\n", report.Recommendation)
+	}
+	if len(report.ActionPlan) > 0 {
+		fmt.Fprintln(env.out, "\nAction plan:")
+		for _, a := range report.ActionPlan {
+			fmt.Fprintf(env.out, "  - %s: %s\n", a.Title, a.Description)
+		}
+	}
+	return nil
+}
+
+// drillQuestion is one multiple-choice question of a plain-text drill.
+type drillQuestion struct {
+	prompt      string
+	options     []string
+	answer      int
+	explanation string
+}
+
+// drillQuestions decodes a validated payload into drill questions.
+func drillQuestions(payload services.QuestionPayload) ([]drillQuestion, error) {
+	var out []drillQuestion
+	switch payload.Mode {
+	case services.ModeVocab:
+		var env services.VocabEnvelope
+		if err := json.Unmarshal(payload.Content, &env); err != nil {
+			return nil, fmt.Errorf("failed to parse vocab questions: %w", err)
+		}
+		for _, q := range env.Questions {
+			out = append(out, drillQuestion{prompt: q.Word, options: q.Options, answer: q.AnswerIndex, explanation: q.Explanation})
+		}
+	case services.ModeGrammar:
+		var env services.GrammarEnvelope
+		if err := json.Unmarshal(payload.Content, &env); err != nil {
+			return nil, fmt.Errorf("failed to parse grammar questions: %w", err)
+		}
+		for _, q := range env.Traps {
+			out = append(out, drillQuestion{prompt: q.Question, options: q.Options, answer: q.AnswerIndex, explanation: q.Explanation})
+		}
+	default:
+		return nil, fmt.Errorf("play supports the vocab and grammar modes, not %q", payload.Mode)
+	}
+	return out, nil
+}
+
+// runPlay asks a vocab or grammar session on standard input and settles it
+// exactly like the TUI does.
+func runPlay(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "play", "[-mode vocab|grammar] [-plain]")
+	mode := fs.String("mode", services.ModeVocab, "vocab or grammar")
+	plain := fs.Bool("plain", false, "print plain text without colours")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *mode != services.ModeVocab && *mode != services.ModeGrammar {
+		return fmt.Errorf("play supports the vocab and grammar modes, not %q", *mode)
+	}
+	paint := func(c lipgloss.TerminalColor, s string) string {
+		if *plain {
+			return s
+		}
+		return lipgloss.NewStyle().Foreground(c).Render(s)
+	}
+
+	p, err := services.NewProvider(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := services.FetchAndValidate(ctx, p, *mode)
+	if err != nil {
+		return err
+	}
+	questions, err := drillQuestions(payload)
+	if err != nil {
+		return err
+	}
+
+	in := bufio.NewScanner(env.in)
+	logs := make([]game.QuestionLog, 0, len(questions))
+	correct := make([]bool, 0, len(questions))
+	for i, q := range questions {
+		fmt.Fprintf(env.out, "\n%s %s\n", paint(components.ColorInfo, fmt.Sprintf("[%d/%d]", i+1, len(questions))), q.prompt)
+		for j, o := range q.options {
+			fmt.Fprintf(env.out, "  %d) %s\n", j+1, o)
+		}
+		started := time.Now()
+		choice, err := readChoice(in, env.out, len(q.options))
+		if err != nil {
+			return err
+		}
+		latency := time.Since(started)
+		ok := choice == q.answer
+		if ok {
+			fmt.Fprintln(env.out, paint(components.ColorPrimary, "Correct!"))
+		} else {
+			fmt.Fprintln(env.out, paint(components.ColorDanger, "Incorrect. Answer: "+q.options[q.answer]))
+		}
+		if q.explanation != "" {
+			fmt.Fprintln(env.out, paint(components.ColorMuted, q.explanation))
+		}
+		correct = append(correct, ok)
+		logs = append(logs, game.QuestionLog{
+			Prompt:        q.prompt,
+			Options:       q.options,
+			Chosen:        q.options[choice],
+			CorrectAnswer: q.options[q.answer],
+			Explanation:   q.explanation,
+			Latency:       latency,
+		})
+	}
+
+	stats := game.FullHeal(env.stats)
+	var summary game.SessionSummary
+	if *mode == services.ModeVocab {
+		answers := make([]game.VocabAnswer, len(logs))
+		for i := range logs {
+			answers[i] = game.VocabAnswer{Correct: correct[i], Item: logs[i]}
+		}
+		stats, summary, err = game.RunVocabSession(ctx, stats, answers)
+	} else {
+		answers := make([]game.GrammarAnswer, len(logs))
+		for i := range logs {
+			answers[i] = game.GrammarAnswer{Correct: correct[i], Item: logs[i]}
+		}
+		stats, summary, err = game.RunGrammarSession(ctx, stats, answers)
+	}
+	if err != nil {
+		return err
+	}
+	env.stats = stats
+
+	fmt.Fprintf(env.out, "\n%s %d/%d correct, %+d EXP, %+d HP\n", paint(components.ColorPrimary, "Result:"), summary.Correct, summary.Total, summary.ExpDelta, summary.HPDelta)
+	if summary.LeveledUp {
+		fmt.Fprintf(env.out, "Level up! Now level %d.\n", stats.Level)
+	}
+	if summary.Fainted {
+		fmt.Fprintln(env.out, paint(components.ColorDanger, "You fainted."))
+	}
+	for _, a := range summary.Unlocked {
+		fmt.Fprintf(env.out, "Achievement unlocked: %s\n", a.ID)
+	}
+	return nil
+}
+
+// readChoice reads a 1-based option number and returns it 0-based.
+func readChoice(in *bufio.Scanner, out io.Writer, n int) (int, error) {
+	for {
+		fmt.Fprintf(out, "Answer (1-%d): ", n)
+		if !in.Scan() {
+			if err := in.Err(); err != nil {
+				return 0, err
+			}
+			return 0, errors.New("input ended before the drill finished")
+		}
+		if choice, err := strconv.Atoi(strings.TrimSpace(in.Text())); err == nil && choice >= 1 && choice <= n {
+			return choice - 1, nil
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/config"
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+)
+
+// newTestEnv opens a fresh database and config directory with one active
+// profile and returns the environment the subcommands run against.
+func newTestEnv(t *testing.T) *cliEnv {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "cli.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+	id, err := game.CreateProfile(ctx, "Aoi", "Grammar Mage")
+	if err != nil {
+		t.Fatalf("CreateProfile error: %v", err)
+	}
+	stats, err := game.ActivateProfile(ctx, id)
+	if err != nil {
+		t.Fatalf("ActivateProfile error: %v", err)
+	}
+	return &cliEnv{
+		cfg:   config.Config{ProfileID: id},
+		stats: stats,
+		in:    strings.NewReader(""),
+		out:   &bytes.Buffer{},
+		err:   &bytes.Buffer{},
+	}
+}
+
+func output(env *cliEnv) string {
+	return env.out.(*bytes.Buffer).String()
+}
+
+// saveSession stores a session of mode for the active profile that ended at
+// ended, with a question log of total questions.
+func saveSession(t *testing.T, env *cliEnv, mode string, ended time.Time, correct, total int) {
+	t.Helper()
+	ctx := context.Background()
+	rec := db.NewSessionRecord(mode, ended, ended)
+	rec.CorrectCount = correct
+	if err := db.SaveSession(ctx, rec); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+	items := make([]db.SessionItem, total)
+	for i := range items {
+		items[i] = db.SessionItem{SessionID: rec.ID, PlayerID: rec.PlayerID, Mode: mode, Seq: i, Prompt: "q", Correct: i < correct}
+	}
+	if err := db.SaveSessionItems(ctx, items); err != nil {
+		t.Fatalf("SaveSessionItems error: %v", err)
+	}
+}
+
+func TestRunCLI_ExitCodes(t *testing.T) {
+	env := newTestEnv(t)
+	cases := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"help"}, 0},
+		{[]string{"stats"}, 0},
+		{[]string{"chess"}, 2},
+		{[]string{"stats", "-h"}, 2},
+		{[]string{"stats", "-bogus"}, 2},
+		{[]string{"history", "-limit", "ten"}, 2},
+		{[]string{"history", "-mode", "chess"}, 1},
+		{[]string{"history", "-from", "2026/01/02"}, 1},
+		{[]string{"import"}, 2},
+		{[]string{"import", "a.json", "b.json"}, 2},
+		{[]string{"import", "-on-conflict", "skip", "a.json"}, 1},
+		{[]string{"import", filepath.Join(t.TempDir(), "missing.json")}, 1},
+		{[]string{"cards", "-format", "pdf"}, 1},
+		{[]string{"play", "-mode", "tavern"}, 1},
+	}
+	for _, c := range cases {
+		if got := runCLI(context.Background(), env, c.args); got != c.want {
+			t.Errorf("runCLI(%q) = %d, want %d", c.args, got, c.want)
+		}
+	}
+}
+
+func TestHistoryFilter(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation(time.DateOnly, s, time.Local)
+		return d
+	}
+	cases := []struct {
+		mode, from, to string
+		want           db.SessionFilter
+		wantErr        bool
+	}{
+		{want: db.SessionFilter{}},
+		{mode: "boss", want: db.SessionFilter{Mode: "boss"}},
+		{mode: "chess", wantErr: true},
+		{from: "2026-03-01", want: db.SessionFilter{Since: day("2026-03-01")}},
+		{to: "2026-03-01", want: db.SessionFilter{Until: day("2026-03-02")}},
+		{from: "2026-03-01", to: "2026-03-01", want: db.SessionFilter{Since: day("2026-03-01"), Until: day("2026-03-02")}},
+		{from: "2026-03-02", to: "2026-03-01", wantErr: true},
+		{from: "March 1", wantErr: true},
+		{to: "2026-13-01", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := historyFilter(c.mode, c.from, c.to)
+		if (err != nil) != c.wantErr {
+			t.Errorf("historyFilter(%q, %q, %q) error = %v, want error %v", c.mode, c.from, c.to, err, c.wantErr)
+			continue
+		}
+		if !c.wantErr && (got.Mode != c.want.Mode || !got.Since.Equal(c.want.Since) || !got.Until.Equal(c.want.Until)) {
+			t.Errorf("historyFilter(%q, %q, %q) = %+v, want %+v", c.mode, c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	env := newTestEnv(t)
+	if err := runStats(context.Background(), env, nil); err != nil {
+		t.Fatalf("runStats error: %v", err)
+	}
+	text := output(env)
+	for _, want := range []string{"Aoi", "Grammar Mage", "Level", "(tier 1)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the stats, got:\n%s", want, text)
+		}
+	}
+
+	env.out = &bytes.Buffer{}
+	if err := runStats(context.Background(), env, []string{"-json"}); err != nil {
+		t.Fatalf("runStats -json error: %v", err)
+	}
+	var stats game.Stats
+	if err := json.Unmarshal([]byte(output(env)), &stats); err != nil || stats != env.stats {
+		t.Fatalf("expected the stats as JSON, got %+v (%v)", stats, err)
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	if err := runHistory(ctx, env, nil); err != nil || !strings.Contains(output(env), "No sessions yet.") {
+		t.Fatalf("expected the empty message, got %q (%v)", output(env), err)
+	}
+
+	march := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.Local) }
+	saveSession(t, env, "vocab", march(1), 4, 5)
+	saveSession(t, env, "grammar", march(2), 2, 3)
+	saveSession(t, env, "vocab", march(3), 5, 5)
+
+	list := func(args ...string) []db.SessionRecord {
+		t.Helper()
+		env.out = &bytes.Buffer{}
+		if err := runHistory(ctx, env, append(args, "-json")); err != nil {
+			t.Fatalf("runHistory(%q) error: %v", args, err)
+		}
+		var sessions []db.SessionRecord
+		if err := json.Unmarshal([]byte(output(env)), &sessions); err != nil {
+			t.Fatalf("runHistory(%q) printed invalid JSON: %v", args, err)
+		}
+		return sessions
+	}
+	if got := list(); len(got) != 3 || got[0].Mode != "vocab" || got[0].CorrectCount != 5 {
+		t.Fatalf("expected all 3 sessions newest first, got %+v", got)
+	}
+	if got := list("-limit", "1"); len(got) != 1 {
+		t.Fatalf("expected -limit to cap the list, got %d", len(got))
+	}
+	if got := list("-mode", "grammar"); len(got) != 1 || got[0].QuestionCount != 3 {
+		t.Fatalf("expected the grammar session with 3 questions, got %+v", got)
+	}
+	if got := list("-from", "2026-03-02", "-to", "2026-03-02"); len(got) != 1 || got[0].Mode != "grammar" {
+		t.Fatalf("expected only the session of March 2, got %+v", got)
+	}
+	if got := list("-to", "2026-02-28"); len(got) != 0 {
+		t.Fatalf("expected nothing before March, got %+v", got)
+	}
+
+	env.out = &bytes.Buffer{}
+	if err := runHistory(ctx, env, []string{"-mode", "grammar"}); err != nil {
+		t.Fatalf("runHistory error: %v", err)
+	}
+	if text := output(env); !strings.Contains(text, "2/3") || !strings.Contains(text, "grammar") {
+		t.Fatalf("expected the grammar row with its 2/3 score, got:\n%s", text)
+	}
+}
+
+func TestRunImport(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	saveSession(t, env, "vocab", time.Now(), 3, 5)
+	path := filepath.Join(t.TempDir(), "aoi.json")
+	if err := runExport(ctx, env, []string{"-o", path}); err != nil {
+		t.Fatalf("runExport error: %v", err)
+	}
+
+	err := runImport(ctx, env, []string{path})
+	if err == nil || !strings.Contains(err.Error(), "-on-conflict merge or replace") {
+		t.Fatalf("expected importing over the same profile to fail with a hint, got %v", err)
+	}
+	if err := runImport(ctx, env, []string{"-on-conflict", "merge", path}); err != nil {
+		t.Fatalf("runImport merge error: %v", err)
+	}
+	if text := output(env); !strings.Contains(text, "Merged Aoi") || !strings.Contains(text, "0 new sessions") {
+		t.Fatalf("expected a merge without new sessions, got %q", text)
+	}
+
+	// A fresh database on another machine.
+	other := newTestEnv(t)
+	other.cfg.Profiles = map[string]config.ProfileSettings{env.cfg.ProfileID: {LangPref: "ja", QuestionsPerSession: 10}}
+	if err := runImport(ctx, other, []string{"-switch", path}); err != nil {
+		t.Fatalf("runImport error: %v", err)
+	}
+	if text := output(other); !strings.Contains(text, "Imported Aoi") || !strings.Contains(text, "1 new sessions") || !strings.Contains(text, "now the active profile") {
+		t.Fatalf("unexpected import output %q", text)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg.ProfileID != env.cfg.ProfileID {
+		t.Fatalf("expected -switch to activate %s, got %q (%v)", env.cfg.ProfileID, cfg.ProfileID, err)
+	}
+	if cfg.LangPref != "ja" || cfg.QuestionsPerSession != 10 {
+		t.Fatalf("expected -switch to keep the profile's own settings, got %q and %d", cfg.LangPref, cfg.QuestionsPerSession)
+	}
+	sessions, err := db.ListSessions(ctx, env.cfg.ProfileID, -1)
+	if err != nil || len(sessions) != 1 || sessions[0].QuestionCount != 5 {
+		t.Fatalf("expected the session and its log to be imported, got %+v (%v)", sessions, err)
+	}
+}
@@ -2,14 +2,8 @@ package main
 
 import (
 	"context"
-	"crypto/rand"
-	"database/sql"
-	"encoding/hex"
-	"errors"
-	"fmt"
 	"log"
 	"os"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -33,7 +27,7 @@ func main() {
 		log.Printf("Warning: failed to load config: %v", err)
 	}
 	if cfg.ProfileID == "" {
-		cfg.ProfileID = newProfileID()
+		cfg.ProfileID = db.NewProfileID()
 		if err := config.SaveConfig(cfg); err != nil {
 			log.Printf("Warning: failed to persist profile id: %v", err)
 		}
@@ -50,21 +44,16 @@ func main() {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
 
-	db.SetProfileID(cfg.ProfileID)
 	ctx := context.Background()
+	stats, err := game.ActivateProfile(ctx, cfg.ProfileID)
+	if err != nil {
+		log.Fatalf("failed to load profile: %v", err)
+	}
 
-	var stats game.Stats
-	if rec, err := db.LoadProfile(ctx, cfg.ProfileID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			stats = game.DefaultStats()
-			if err := game.SaveStats(ctx, stats); err != nil {
-				log.Printf("failed to seed profile: %v", err)
-			}
-		} else {
-			log.Fatalf("failed to load profile: %v", err)
-		}
-	} else {
-		stats = game.StatsFromProfile(rec)
+	// Subcommands run headless and skip the TUI.
+	if len(os.Args) > 1 {
+		env := &cliEnv{cfg: cfg, stats: stats, in: os.Stdin, out: os.Stdout, err: os.Stderr}
+		os.Exit(runCLI(ctx, env, os.Args[1:]))
 	}
 
 	p := tea.NewProgram(ui.NewRootModel(stats, cfg), tea.WithAltScreen())
@@ -72,12 +61,3 @@ func main() {
 		log.Fatalf("failed to run program: %v", err)
 	}
 }
-
-func newProfileID() string {
-	var buf [16]byte
-	if _, err := rand.Read(buf[:]); err != nil {
-		log.Printf("Warning: failed to generate profile id: %v", err)
-		return fmt.Sprintf("player-%d", time.Now().UnixNano())
-	}
-	return hex.EncodeToString(buf[:])
-}
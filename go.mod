@@ -10,6 +10,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/mattn/go-sqlite3 v1.14.32
 	google.golang.org/api v0.257.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
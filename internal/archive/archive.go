@@ -0,0 +1,360 @@
+// Package archive moves a player's progress between databases as a
+// versioned JSON document.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+const (
+	// Format identifies an archive file.
+	Format = "tui-english-quest"
+	// Version is the archive layout written by Export. Import reads this
+	// version and older ones.
+	Version = 1
+)
+
+var (
+	// ErrNotArchive is returned when the input is not an archive.
+	ErrNotArchive = errors.New("not a tui-english-quest archive")
+	// ErrUnsupportedVersion is returned for archives written by a newer
+	// version of the game.
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrProfileExists is returned by Import with OnConflictFail when the
+	// archived profile is already in the database.
+	ErrProfileExists = errors.New("profile already exists")
+)
+
+// Conflict decides what Import does when the archived profile already exists.
+type Conflict int
+
+const (
+	// OnConflictFail leaves the database untouched and returns ErrProfileExists.
+	OnConflictFail Conflict = iota
+	// OnConflictMerge adds the archive's history to the stored profile; see
+	// db.ImportPlayerData for which side wins.
+	OnConflictMerge
+	// OnConflictReplace deletes the stored profile and restores the archive.
+	OnConflictReplace
+)
+
+// ParseConflict parses "fail", "merge" or "replace".
+func ParseConflict(s string) (Conflict, error) {
+	switch s {
+	case "fail":
+		return OnConflictFail, nil
+	case "merge":
+		return OnConflictMerge, nil
+	case "replace":
+		return OnConflictReplace, nil
+	}
+	return OnConflictFail, fmt.Errorf("unknown conflict mode %q (want fail, merge or replace)", s)
+}
+
+// Archive is the document written by Export.
+type Archive struct {
+	Format        string                 `json:"format"`
+	Version       int                    `json:"version"`
+	ExportedAt    time.Time              `json:"exported_at"`
+	Profile       Profile                `json:"profile"`
+	Sessions      []Session              `json:"sessions"`
+	Equipment     Equipment              `json:"equipment"`
+	Items         map[string]int         `json:"items,omitempty"`
+	Achievements  map[string]time.Time   `json:"achievements,omitempty"`
+	BossVictories map[string]BossVictory `json:"boss_victories,omitempty"`
+	Reviews       []ReviewItem           `json:"reviews,omitempty"`
+	Analyses      []Analysis             `json:"analyses,omitempty"`
+}
+
+// Profile holds the player's stats.
+type Profile struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Class           string    `json:"class"`
+	Level           int       `json:"level"`
+	Exp             int       `json:"exp"`
+	NextLevelExp    int       `json:"next_level_exp"`
+	HP              int       `json:"hp"`
+	MaxHP           int       `json:"max_hp"`
+	Attack          int       `json:"attack"`
+	Defense         float64   `json:"defense"`
+	Combo           int       `json:"combo"`
+	StreakDays      int       `json:"streak_days"`
+	Gold            int       `json:"gold"`
+	ExpBoost        float64   `json:"exp_boost"`
+	DamageReduction float64   `json:"damage_reduction"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Session is a played session with its question log.
+type Session struct {
+	ID            string        `json:"id"`
+	Mode          string        `json:"mode"`
+	StartedAt     time.Time     `json:"started_at"`
+	EndedAt       time.Time     `json:"ended_at"`
+	QuestionSetID string        `json:"question_set_id,omitempty"`
+	CorrectCount  int           `json:"correct_count"`
+	BestCombo     int           `json:"best_combo"`
+	ExpGained     int           `json:"exp_gained"`
+	ExpLost       int           `json:"exp_lost"`
+	HPDelta       int           `json:"hp_delta"`
+	GoldDelta     int           `json:"gold_delta"`
+	DefenseDelta  float64       `json:"defense_delta"`
+	Fainted       bool          `json:"fainted"`
+	LeveledUp     bool          `json:"leveled_up"`
+	Review        bool          `json:"review,omitempty"`
+	Items         []SessionItem `json:"items,omitempty"`
+}
+
+// SessionItem is one answered question.
+type SessionItem struct {
+	Seq           int       `json:"seq"`
+	Prompt        string    `json:"prompt"`
+	Options       []string  `json:"options,omitempty"`
+	Chosen        string    `json:"chosen"`
+	CorrectAnswer string    `json:"correct_answer"`
+	Explanation   string    `json:"explanation,omitempty"`
+	Correct       bool      `json:"correct"`
+	Outcome       string    `json:"outcome,omitempty"`
+	LatencyMS     int64     `json:"latency_ms,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Equipment lists the bought items and what is worn in each slot.
+type Equipment struct {
+	Owned    []string          `json:"owned,omitempty"`
+	Equipped map[string]string `json:"equipped,omitempty"`
+}
+
+// BossVictory records the wins against one boss.
+type BossVictory struct {
+	Victories  int       `json:"victories"`
+	FirstWonAt time.Time `json:"first_won_at"`
+	LastWonAt  time.Time `json:"last_won_at"`
+}
+
+// ReviewItem is a spaced-repetition card.
+type ReviewItem struct {
+	Mode           string          `json:"mode"`
+	ItemKey        string          `json:"item_key"`
+	Payload        json.RawMessage `json:"payload"`
+	Ease           float64         `json:"ease"`
+	IntervalDays   int             `json:"interval_days"`
+	Repetitions    int             `json:"repetitions"`
+	Lapses         int             `json:"lapses"`
+	DueAt          time.Time       `json:"due_at"`
+	LastReviewedAt time.Time       `json:"last_reviewed_at"`
+}
+
+// Analysis is a saved weakness analysis.
+type Analysis struct {
+	ID             string          `json:"id"`
+	AnalyzedRange  int             `json:"analyzed_range"`
+	WeakPoints     json.RawMessage `json:"weak_points,omitempty"`
+	StrengthPoints json.RawMessage `json:"strength_points,omitempty"`
+	Recommendation string          `json:"recommendation"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+// Result describes what Import restored.
+type Result struct {
+	ProfileID string
+	Name      string
+	Level     int
+	Sessions  int  // sessions that were not in the database before
+	Merged    bool // the profile already existed and was merged into
+}
+
+// Export writes the archive of playerID to w.
+func Export(ctx context.Context, w io.Writer, playerID string) error {
+	data, err := db.LoadPlayerData(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to load player data: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fromPlayerData(data, time.Now()))
+}
+
+// Import reads an archive from r and restores it into the database, handling
+// an existing profile with the same ID as c says.
+func Import(ctx context.Context, r io.Reader, c Conflict) (Result, error) {
+	a, err := Read(r)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{ProfileID: a.Profile.ID, Name: a.Profile.Name, Level: a.Profile.Level}
+	_, err = db.LoadProfile(ctx, a.Profile.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return res, err
+	}
+	exists := err == nil
+	if exists && c == OnConflictFail {
+		return res, fmt.Errorf("%w: %s (%s)", ErrProfileExists, a.Profile.Name, a.Profile.ID)
+	}
+	res.Merged = exists && c == OnConflictMerge
+	res.Sessions, err = db.ImportPlayerData(ctx, a.toPlayerData(), c == OnConflictReplace)
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// Read decodes and checks an archive.
+func Read(r io.Reader) (Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return a, fmt.Errorf("%w: %v", ErrNotArchive, err)
+	}
+	if a.Format != Format || a.Version < 1 {
+		return a, ErrNotArchive
+	}
+	if a.Version > Version {
+		return a, fmt.Errorf("%w: %d (this build reads up to %d)", ErrUnsupportedVersion, a.Version, Version)
+	}
+	if a.Profile.ID == "" || a.Profile.Level < 1 {
+		return a, fmt.Errorf("%w: missing profile", ErrNotArchive)
+	}
+	return a, nil
+}
+
+func fromPlayerData(d db.PlayerData, now time.Time) Archive {
+	p := d.Profile
+	a := Archive{
+		Format:     Format,
+		Version:    Version,
+		ExportedAt: now,
+		Profile: Profile{
+			ID: p.ID, Name: p.Name, Class: p.Class, Level: p.Level, Exp: p.Exp, NextLevelExp: p.NextLevelExp,
+			HP: p.HP, MaxHP: p.MaxHP, Attack: p.Attack, Defense: p.Defense, Combo: p.Combo,
+			StreakDays: p.StreakDays, Gold: p.Gold, ExpBoost: p.ExpBoost, DamageReduction: p.DamageReduction,
+			UpdatedAt: p.UpdatedAt,
+		},
+		Sessions:      make([]Session, 0, len(d.Sessions)),
+		Equipment:     Equipment{Owned: d.Equipment, Equipped: d.Equipped},
+		Items:         d.Items,
+		Achievements:  d.Achievements,
+		BossVictories: map[string]BossVictory{},
+	}
+
+	items := map[string][]SessionItem{}
+	for _, it := range d.SessionItems {
+		items[it.SessionID] = append(items[it.SessionID], SessionItem{
+			Seq: it.Seq, Prompt: it.Prompt, Options: it.Options, Chosen: it.Chosen,
+			CorrectAnswer: it.CorrectAnswer, Explanation: it.Explanation, Correct: it.Correct,
+			Outcome: it.Outcome, LatencyMS: it.Latency.Milliseconds(), CreatedAt: it.CreatedAt,
+		})
+	}
+	for _, s := range d.Sessions {
+		a.Sessions = append(a.Sessions, Session{
+			ID: s.ID, Mode: s.Mode, StartedAt: s.StartedAt, EndedAt: s.EndedAt, QuestionSetID: s.QuestionSetID,
+			CorrectCount: s.CorrectCount, BestCombo: s.BestCombo, ExpGained: s.ExpGained, ExpLost: s.ExpLost,
+			HPDelta: s.HPDelta, GoldDelta: s.GoldDelta, DefenseDelta: s.DefenseDelta,
+			Fainted: s.Fainted, LeveledUp: s.LeveledUp, Review: s.Review, Items: items[s.ID],
+		})
+	}
+	for id, v := range d.BossVictories {
+		a.BossVictories[id] = BossVictory{Victories: v.Victories, FirstWonAt: v.FirstWonAt, LastWonAt: v.LastWonAt}
+	}
+	for _, r := range d.ReviewItems {
+		a.Reviews = append(a.Reviews, ReviewItem{
+			Mode: r.Mode, ItemKey: r.ItemKey, Payload: rawJSON(r.Payload), Ease: r.Ease,
+			IntervalDays: r.IntervalDays, Repetitions: r.Repetitions, Lapses: r.Lapses,
+			DueAt: r.DueAt, LastReviewedAt: r.LastReviewedAt,
+		})
+	}
+	for _, an := range d.Analyses {
+		a.Analyses = append(a.Analyses, Analysis{
+			ID: an.ID, AnalyzedRange: an.AnalyzedRange, WeakPoints: rawJSON(an.WeakPoints),
+			StrengthPoints: rawJSON(an.StrengthPoints), Recommendation: an.Recommendation,
+			GeneratedAt: an.GeneratedAt,
+		})
+	}
+	return a
+}
+
+func (a Archive) toPlayerData() db.PlayerData {
+	p := a.Profile
+	d := db.PlayerData{
+		Profile: db.ProfileRecord{
+			ID: p.ID, Name: p.Name, Class: p.Class, Level: p.Level, Exp: p.Exp, NextLevelExp: p.NextLevelExp,
+			HP: p.HP, MaxHP: p.MaxHP, Attack: p.Attack, Defense: p.Defense, Combo: p.Combo,
+			StreakDays: p.StreakDays, Gold: p.Gold, ExpBoost: p.ExpBoost, DamageReduction: p.DamageReduction,
+			UpdatedAt: p.UpdatedAt,
+		},
+		Items:         a.Items,
+		Equipment:     a.Equipment.Owned,
+		Equipped:      a.Equipment.Equipped,
+		Achievements:  a.Achievements,
+		BossVictories: map[string]db.BossVictory{},
+	}
+	for _, s := range a.Sessions {
+		d.Sessions = append(d.Sessions, db.SessionRecord{
+			ID: s.ID, PlayerID: p.ID, Mode: s.Mode, StartedAt: s.StartedAt, EndedAt: s.EndedAt,
+			QuestionSetID: s.QuestionSetID, CorrectCount: s.CorrectCount, BestCombo: s.BestCombo,
+			ExpGained: s.ExpGained, ExpLost: s.ExpLost, HPDelta: s.HPDelta, GoldDelta: s.GoldDelta,
+			DefenseDelta: s.DefenseDelta, Fainted: s.Fainted, LeveledUp: s.LeveledUp, Review: s.Review,
+		})
+		for _, it := range s.Items {
+			d.SessionItems = append(d.SessionItems, db.SessionItem{
+				SessionID: s.ID, PlayerID: p.ID, Mode: s.Mode, Seq: it.Seq, Prompt: it.Prompt,
+				Options: it.Options, Chosen: it.Chosen, CorrectAnswer: it.CorrectAnswer,
+				Explanation: it.Explanation, Correct: it.Correct, Outcome: it.Outcome,
+				Latency: time.Duration(it.LatencyMS) * time.Millisecond, CreatedAt: it.CreatedAt,
+			})
+		}
+	}
+	for id, v := range a.BossVictories {
+		d.BossVictories[id] = db.BossVictory{Victories: v.Victories, FirstWonAt: v.FirstWonAt, LastWonAt: v.LastWonAt}
+	}
+	for _, r := range a.Reviews {
+		d.ReviewItems = append(d.ReviewItems, db.ReviewItemRecord{
+			PlayerID: p.ID, Mode: r.Mode, ItemKey: r.ItemKey, Payload: rawText(r.Payload), Ease: r.Ease,
+			IntervalDays: r.IntervalDays, Repetitions: r.Repetitions, Lapses: r.Lapses,
+			DueAt: r.DueAt, LastReviewedAt: r.LastReviewedAt,
+		})
+	}
+	for _, an := range a.Analyses {
+		d.Analyses = append(d.Analyses, db.AnalysisRecord{
+			ID: an.ID, PlayerID: p.ID, AnalyzedRange: an.AnalyzedRange, WeakPoints: rawText(an.WeakPoints),
+			StrengthPoints: rawText(an.StrengthPoints), Recommendation: an.Recommendation,
+			GeneratedAt: an.GeneratedAt,
+		})
+	}
+	return d
+}
+
+// rawJSON embeds s in the archive as JSON when it is valid JSON, and as a
+// JSON string otherwise.
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	if json.Valid([]byte(s)) {
+		return json.RawMessage(s)
+	}
+	b, _ := json.Marshal(s)
+	return b
+}
+
+// rawText reverses rawJSON, undoing the indentation Export adds.
+func rawText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
@@ -0,0 +1,197 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func seedPlayer(t *testing.T, ctx context.Context, id string) {
+	t.Helper()
+	if err := db.SaveProfile(ctx, db.ProfileRecord{
+		ID: id, Name: "Aoi", Class: "Grammar Mage", Level: 7, Exp: 12, NextLevelExp: 90,
+		HP: 80, MaxHP: 120, Attack: 10, Defense: 5, Gold: 340,
+	}); err != nil {
+		t.Fatalf("SaveProfile error: %v", err)
+	}
+	ended := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	rec := db.SessionRecord{ID: "s1", PlayerID: id, Mode: "vocab", StartedAt: ended.Add(-time.Minute), EndedAt: ended, CorrectCount: 4, ExpGained: 20}
+	if err := db.SaveSession(ctx, rec); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+	if err := db.SaveSessionItems(ctx, []db.SessionItem{
+		{SessionID: "s1", PlayerID: id, Mode: "vocab", Seq: 0, Prompt: "sufficient", Options: []string{"enough", "tiny"}, Chosen: "tiny", CorrectAnswer: "enough", Latency: 2500 * time.Millisecond},
+	}); err != nil {
+		t.Fatalf("SaveSessionItems error: %v", err)
+	}
+	if err := db.AddItems(ctx, id, "hp_potion", 2); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+	if err := db.AddEquipment(ctx, id, "iron_sword"); err != nil {
+		t.Fatalf("AddEquipment error: %v", err)
+	}
+	if err := db.EquipItem(ctx, id, "iron_sword"); err != nil {
+		t.Fatalf("EquipItem error: %v", err)
+	}
+	if _, err := db.UnlockAchievement(ctx, id, "first_steps", ended); err != nil {
+		t.Fatalf("UnlockAchievement error: %v", err)
+	}
+	if _, err := db.RecordBossVictory(ctx, id, "stone_golem", ended); err != nil {
+		t.Fatalf("RecordBossVictory error: %v", err)
+	}
+	if err := db.SaveReviewItem(ctx, db.ReviewItemRecord{PlayerID: id, Mode: "vocab", ItemKey: "sufficient", Payload: `{"word":"sufficient"}`, Ease: 2.5, IntervalDays: 1, DueAt: ended}); err != nil {
+		t.Fatalf("SaveReviewItem error: %v", err)
+	}
+	if err := db.SaveAnalysis(ctx, db.AnalysisRecord{PlayerID: id, AnalyzedRange: 1, WeakPoints: `[{"Mode":"vocab"}]`, Recommendation: "Drill vocab"}); err != nil {
+		t.Fatalf("SaveAnalysis error: %v", err)
+	}
+}
+
+func export(t *testing.T, ctx context.Context, id string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Export(ctx, &buf, id); err != nil {
+		t.Fatalf("Export error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestExportImport_RestoresEverythingInAnotherDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := db.InitDB(filepath.Join(dir, "laptop-a.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	seedPlayer(t, ctx, "p1")
+	data := export(t, ctx, "p1")
+
+	if err := db.InitDB(filepath.Join(dir, "laptop-b.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	res, err := Import(ctx, bytes.NewReader(data), OnConflictFail)
+	if err != nil {
+		t.Fatalf("Import error: %v", err)
+	}
+	if res.ProfileID != "p1" || res.Sessions != 1 || res.Merged {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	got, err := db.LoadPlayerData(ctx, "p1")
+	if err != nil {
+		t.Fatalf("LoadPlayerData error: %v", err)
+	}
+	if got.Profile.Level != 7 || got.Profile.Gold != 340 || got.Profile.Class != "Grammar Mage" {
+		t.Fatalf("profile not restored: %+v", got.Profile)
+	}
+	if len(got.Sessions) != 1 || got.Sessions[0].CorrectCount != 4 {
+		t.Fatalf("sessions not restored: %+v", got.Sessions)
+	}
+	if len(got.SessionItems) != 1 || got.SessionItems[0].Chosen != "tiny" || got.SessionItems[0].Latency != 2500*time.Millisecond {
+		t.Fatalf("session items not restored: %+v", got.SessionItems)
+	}
+	if got.Items["hp_potion"] != 2 || got.Equipped["weapon"] != "iron_sword" || len(got.Equipment) != 1 {
+		t.Fatalf("items or equipment not restored: %v %v %v", got.Items, got.Equipped, got.Equipment)
+	}
+	if _, ok := got.Achievements["first_steps"]; !ok || got.BossVictories["stone_golem"].Victories != 1 {
+		t.Fatalf("achievements or boss victories not restored: %v %v", got.Achievements, got.BossVictories)
+	}
+	if len(got.ReviewItems) != 1 || got.ReviewItems[0].Payload != `{"word":"sufficient"}` {
+		t.Fatalf("review items not restored: %+v", got.ReviewItems)
+	}
+	if len(got.Analyses) != 1 || got.Analyses[0].WeakPoints != `[{"Mode":"vocab"}]` {
+		t.Fatalf("analyses not restored: %+v", got.Analyses)
+	}
+
+	if _, err := Import(ctx, bytes.NewReader(data), OnConflictFail); !errors.Is(err, ErrProfileExists) {
+		t.Fatalf("expected ErrProfileExists on a second import, got %v", err)
+	}
+}
+
+func TestImport_MergeAndReplace(t *testing.T) {
+	ctx := context.Background()
+	if err := db.InitDB(filepath.Join(t.TempDir(), "merge.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	seedPlayer(t, ctx, "p1")
+	var a Archive
+	if err := json.Unmarshal(export(t, ctx, "p1"), &a); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+
+	// Progress made on this machine after the export.
+	local := db.SessionRecord{ID: "s-local", PlayerID: "p1", Mode: "grammar", EndedAt: time.Now()}
+	if err := db.SaveSession(ctx, local); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+	if err := db.AddItems(ctx, "p1", "hp_potion", 3); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+	// Progress made on the other machine, saved later still.
+	a.Sessions = append(a.Sessions, Session{ID: "s-remote", Mode: "spelling", EndedAt: time.Now(), CorrectCount: 5})
+	a.Profile.Gold = 999
+	a.Profile.UpdatedAt = time.Now().Add(time.Hour)
+	a.Items["hp_potion"] = 1
+	a.BossVictories["stone_golem"] = BossVictory{Victories: 3, FirstWonAt: time.Now(), LastWonAt: time.Now()}
+	raw, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("encode archive: %v", err)
+	}
+
+	res, err := Import(ctx, bytes.NewReader(raw), OnConflictMerge)
+	if err != nil {
+		t.Fatalf("merge Import error: %v", err)
+	}
+	if !res.Merged || res.Sessions != 1 {
+		t.Fatalf("expected one new session merged, got %+v", res)
+	}
+	got, err := db.LoadPlayerData(ctx, "p1")
+	if err != nil {
+		t.Fatalf("LoadPlayerData error: %v", err)
+	}
+	if len(got.Sessions) != 3 {
+		t.Fatalf("expected local and remote sessions to be kept, got %d", len(got.Sessions))
+	}
+	if got.Profile.Gold != 999 {
+		t.Fatalf("expected the newer profile to win, got gold %d", got.Profile.Gold)
+	}
+	if got.Items["hp_potion"] != 5 || got.BossVictories["stone_golem"].Victories != 3 {
+		t.Fatalf("expected the larger counts, got %v %v", got.Items, got.BossVictories)
+	}
+
+	if _, err := Import(ctx, bytes.NewReader(raw), OnConflictReplace); err != nil {
+		t.Fatalf("replace Import error: %v", err)
+	}
+	got, err = db.LoadPlayerData(ctx, "p1")
+	if err != nil {
+		t.Fatalf("LoadPlayerData error: %v", err)
+	}
+	if len(got.Sessions) != 2 || got.Items["hp_potion"] != 1 {
+		t.Fatalf("expected only the archive's data after replace, got %d sessions and %v", len(got.Sessions), got.Items)
+	}
+	if len(got.SessionItems) != 1 {
+		t.Fatalf("expected the archived question log, got %d items", len(got.SessionItems))
+	}
+}
+
+func TestRead_RejectsForeignAndNewerArchives(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input string
+		want  error
+	}{
+		"not json":      {`hello`, ErrNotArchive},
+		"other format":  {`{"format":"other","version":1,"profile":{"id":"p1","level":1}}`, ErrNotArchive},
+		"newer version": {`{"format":"tui-english-quest","version":99,"profile":{"id":"p1","level":1}}`, ErrUnsupportedVersion},
+		"no profile":    {`{"format":"tui-english-quest","version":1}`, ErrNotArchive},
+	} {
+		if _, err := Read(strings.NewReader(tc.input)); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package cards turns the vocabulary and spelling questions a player has
+// answered into flashcards and writes them as CSV or Anki-importable TSV.
+package cards
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+// Modes lists the modes whose questions make cards. A vocab card shows the
+// word and asks for its meaning; a spelling card shows the Japanese hint
+// and asks for the spelling.
+var Modes = []string{"vocab", "spelling"}
+
+// Status says whether the player last got a card's question right.
+type Status string
+
+const (
+	StatusMissed  Status = "missed"
+	StatusLearned Status = "learned"
+)
+
+// Card is one question the player has answered, with every attempt at it
+// folded in.
+type Card struct {
+	Mode        string
+	Front       string
+	Back        string
+	Explanation string
+	Status      Status // from the latest attempt
+	Attempts    int
+	Misses      int
+	LastSeen    time.Time
+}
+
+// Tags returns the Anki tags of c: the game, its mode and its status.
+func (c Card) Tags() []string {
+	return []string{"english-quest", "english-quest::" + c.Mode, "english-quest::" + string(c.Status)}
+}
+
+// Filter selects cards. Empty fields select everything.
+type Filter struct {
+	Modes  []string
+	Status Status
+}
+
+// ParseFilter builds a Filter from a mode ("vocab", "spelling" or "all") and
+// a status ("missed", "learned" or "all").
+func ParseFilter(mode, status string) (Filter, error) {
+	var f Filter
+	switch mode {
+	case "", "all":
+	case "vocab", "spelling":
+		f.Modes = []string{mode}
+	default:
+		return f, fmt.Errorf("unknown card mode %q (want vocab, spelling or all)", mode)
+	}
+	switch Status(status) {
+	case "", "all":
+	case StatusMissed, StatusLearned:
+		f.Status = Status(status)
+	default:
+		return f, fmt.Errorf("unknown card status %q (want missed, learned or all)", status)
+	}
+	return f, nil
+}
+
+// Load builds the player's cards from the session history.
+func Load(ctx context.Context, playerID string, f Filter) ([]Card, error) {
+	modes := f.Modes
+	if len(modes) == 0 {
+		modes = Modes
+	}
+	items, err := db.ListPlayerSessionItems(ctx, playerID, modes...)
+	if err != nil {
+		return nil, err
+	}
+	return Build(items, f), nil
+}
+
+// Build folds answered questions, oldest first, into cards matching f,
+// ordered by mode and front. Questions asked again with the same front and
+// back count as further attempts at the same card.
+func Build(items []db.SessionItem, f Filter) []Card {
+	byKey := map[string]*Card{}
+	var order []*Card
+	for _, it := range items {
+		if !wanted(it.Mode, f.Modes) || strings.TrimSpace(it.Prompt) == "" || it.CorrectAnswer == "" {
+			continue
+		}
+		key := it.Mode + "\x00" + strings.ToLower(strings.TrimSpace(it.Prompt)) + "\x00" + strings.ToLower(it.CorrectAnswer)
+		c := byKey[key]
+		if c == nil {
+			c = &Card{Mode: it.Mode, Front: strings.TrimSpace(it.Prompt), Back: it.CorrectAnswer}
+			byKey[key] = c
+			order = append(order, c)
+		}
+		c.Attempts++
+		c.Status = StatusLearned
+		if !it.Correct {
+			c.Misses++
+			c.Status = StatusMissed
+		}
+		if it.Explanation != "" {
+			c.Explanation = it.Explanation
+		}
+		c.LastSeen = it.CreatedAt
+	}
+
+	out := make([]Card, 0, len(order))
+	for _, c := range order {
+		if f.Status == "" || c.Status == f.Status {
+			out = append(out, *c)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Mode != out[j].Mode {
+			return modeRank(out[i].Mode) < modeRank(out[j].Mode)
+		}
+		return strings.ToLower(out[i].Front) < strings.ToLower(out[j].Front)
+	})
+	return out
+}
+
+// modeRank orders modes as in Modes.
+func modeRank(mode string) int {
+	for i, m := range Modes {
+		if m == mode {
+			return i
+		}
+	}
+	return len(Modes)
+}
+
+func wanted(mode string, modes []string) bool {
+	if len(modes) == 0 {
+		modes = Modes
+	}
+	for _, m := range modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteCSV writes cards as CSV with a header row.
+func WriteCSV(w io.Writer, cards []Card) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"front", "back", "explanation", "mode", "status", "attempts", "misses", "last_seen", "tags"})
+	for _, c := range cards {
+		last := ""
+		if !c.LastSeen.IsZero() {
+			last = c.LastSeen.Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			c.Front, c.Back, c.Explanation, c.Mode, string(c.Status),
+			strconv.Itoa(c.Attempts), strconv.Itoa(c.Misses), last, strings.Join(c.Tags(), " "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteAnki writes cards as tab-separated text for Anki's File > Import:
+// the front, the back with the explanation below it, and the tags. The
+// header lines tell Anki the separator, that fields are HTML and which
+// column holds the tags.
+func WriteAnki(w io.Writer, cards []Card) error {
+	if _, err := io.WriteString(w, "#separator:tab\n#html:true\n#tags column:3\n"); err != nil {
+		return err
+	}
+	for _, c := range cards {
+		back := ankiField(c.Back)
+		if c.Explanation != "" {
+			back += "<br><br>" + ankiField(c.Explanation)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", ankiField(c.Front), back, strings.Join(c.Tags(), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ankiField escapes s for an HTML field of a tab-separated line.
+func ankiField(s string) string {
+	s = html.EscapeString(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return strings.ReplaceAll(s, "\t", " ")
+}
+
+// WriteFiles writes cards to dir as a CSV file and an Anki TSV file named
+// after the date of now, and returns their paths.
+func WriteFiles(dir string, cards []Card, now time.Time) (csvPath, ankiPath string, err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+	base := filepath.Join(dir, "english-quest-cards-"+now.Format("20060102"))
+	csvPath, ankiPath = base+".csv", base+".anki.tsv"
+	if err := writeFile(csvPath, cards, WriteCSV); err != nil {
+		return "", "", err
+	}
+	if err := writeFile(ankiPath, cards, WriteAnki); err != nil {
+		return "", "", err
+	}
+	return csvPath, ankiPath, nil
+}
+
+func writeFile(path string, cards []Card, write func(io.Writer, []Card) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, cards); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,104 @@
+package cards
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func item(mode, prompt, answer string, correct bool, at time.Time) db.SessionItem {
+	return db.SessionItem{Mode: mode, Prompt: prompt, CorrectAnswer: answer, Correct: correct, Explanation: prompt + " explained", CreatedAt: at}
+}
+
+func TestBuild_FoldsAttemptsAndFilters(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	items := []db.SessionItem{
+		item("vocab", "sufficient", "enough", false, t0),
+		item("spelling", "必要な", "necessary", false, t0.Add(time.Minute)),
+		item("grammar", "He ___ there.", "went", false, t0.Add(2*time.Minute)),
+		item("vocab", "Sufficient ", "enough", true, t0.Add(time.Hour)),
+		item("vocab", "obvious", "easy to see", false, t0.Add(2*time.Hour)),
+	}
+
+	all := Build(items, Filter{})
+	if len(all) != 3 {
+		t.Fatalf("expected 3 cards without grammar, got %+v", all)
+	}
+	if all[0].Front != "obvious" || all[1].Front != "sufficient" || all[2].Mode != "spelling" {
+		t.Fatalf("expected vocab cards by front, then spelling, got %+v", all)
+	}
+	s := all[1]
+	if s.Attempts != 2 || s.Misses != 1 || s.Status != StatusLearned || !s.LastSeen.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("expected the retried word to be learned after 2 attempts, got %+v", s)
+	}
+
+	missed := Build(items, Filter{Status: StatusMissed})
+	if len(missed) != 2 || missed[0].Front != "obvious" || missed[1].Front != "必要な" {
+		t.Fatalf("expected the two missed cards, got %+v", missed)
+	}
+	spelling := Build(items, Filter{Modes: []string{"spelling"}})
+	if len(spelling) != 1 || spelling[0].Back != "necessary" {
+		t.Fatalf("expected only the spelling card, got %+v", spelling)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("spelling", "missed")
+	if err != nil || len(f.Modes) != 1 || f.Modes[0] != "spelling" || f.Status != StatusMissed {
+		t.Fatalf("unexpected filter %+v (%v)", f, err)
+	}
+	if f, err := ParseFilter("all", "all"); err != nil || f.Modes != nil || f.Status != "" {
+		t.Fatalf("expected an empty filter, got %+v (%v)", f, err)
+	}
+	if _, err := ParseFilter("grammar", "all"); err == nil {
+		t.Fatal("expected an error for a mode without cards")
+	}
+	if _, err := ParseFilter("vocab", "forgotten"); err == nil {
+		t.Fatal("expected an error for an unknown status")
+	}
+}
+
+func TestWriteAnki_EscapesFieldsAndTagsByModeAndStatus(t *testing.T) {
+	cards := []Card{{Mode: "vocab", Front: "a <b>\tc", Back: "x & y", Explanation: "line one\nline two", Status: StatusMissed}}
+	var buf bytes.Buffer
+	if err := WriteAnki(&buf, cards); err != nil {
+		t.Fatalf("WriteAnki error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 || lines[0] != "#separator:tab" || lines[2] != "#tags column:3" {
+		t.Fatalf("unexpected header: %q", lines)
+	}
+	fields := strings.Split(lines[3], "\t")
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 tab-separated fields, got %q", lines[3])
+	}
+	if fields[0] != "a &lt;b&gt; c" || fields[1] != "x &amp; y<br><br>line one<br>line two" {
+		t.Fatalf("fields not escaped: %q", fields)
+	}
+	if fields[2] != "english-quest english-quest::vocab english-quest::missed" {
+		t.Fatalf("unexpected tags %q", fields[2])
+	}
+}
+
+func TestWriteCSV_HasHeaderAndOneRowPerCard(t *testing.T) {
+	cards := []Card{{Mode: "spelling", Front: "必要な", Back: "necessary", Explanation: "needed, \"required\"", Status: StatusLearned, Attempts: 3, Misses: 1}}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, cards); err != nil {
+		t.Fatalf("WriteCSV error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV does not parse: %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "front" {
+		t.Fatalf("unexpected rows %q", rows)
+	}
+	want := []string{"必要な", "necessary", "needed, \"required\"", "spelling", "learned", "3", "1", "", "english-quest english-quest::spelling english-quest::learned"}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, rows[1])
+	}
+}
@@ -13,12 +13,47 @@ type Config struct {
 	LangPref            string `json:"lang_pref"` // "en"/"ja" ("both" removed)
 	ApiKey              string `json:"api_key"`
 	QuestionsPerSession int    `json:"questions_per_session"`
-	ProfileID           string `json:"profile_id"`
+	// QuestionTimeLimit is the per-question countdown in seconds for the
+	// combat modes; 0 turns the countdown off.
+	QuestionTimeLimit int    `json:"question_time_limit"`
+	ProfileID         string `json:"profile_id"`
+	// Backend selects the question generator: "gemini" (default), "openai"
+	// or "offline" (question packs only).
+	Backend       string `json:"backend"`
+	OpenAIBaseURL string `json:"openai_base_url"` // e.g. http://localhost:8080/v1
+	OpenAIModel   string `json:"openai_model"`
+	OpenAIApiKey  string `json:"openai_api_key"`
+	// PacksDir holds offline question packs; empty means PacksPath().
+	PacksDir string `json:"packs_dir"`
+	// Profiles keeps each profile's own preferences, keyed by profile ID.
+	// The top-level values belong to ProfileID, the active profile.
+	Profiles map[string]ProfileSettings `json:"profiles,omitempty"`
+}
+
+// ProfileSettings are the preferences stored separately for every profile.
+type ProfileSettings struct {
+	LangPref            string `json:"lang_pref,omitempty"`
+	QuestionsPerSession int    `json:"questions_per_session,omitempty"`
+}
+
+// WithProfile makes id the active profile and applies its saved preferences.
+// A profile without saved preferences starts from the current ones.
+func (c Config) WithProfile(id string) Config {
+	c.ProfileID = id
+	if ps, ok := c.Profiles[id]; ok {
+		if ps.LangPref != "" {
+			c.LangPref = ps.LangPref
+		}
+		if ps.QuestionsPerSession > 0 {
+			c.QuestionsPerSession = ps.QuestionsPerSession
+		}
+	}
+	return c
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() Config {
-	return Config{LangPref: "en", ApiKey: "", QuestionsPerSession: 5, ProfileID: ""}
+	return Config{LangPref: "en", ApiKey: "", QuestionsPerSession: 5, ProfileID: "", Backend: "gemini"}
 }
 
 // ConfigPath returns the platform-appropriate path for the config file.
@@ -41,6 +76,26 @@ func ConfigPath() (string, error) {
 	return p, nil
 }
 
+// PacksPath returns the default directory for offline question packs,
+// next to the config file.
+func PacksPath() (string, error) {
+	p, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(p), "packs"), nil
+}
+
+// ExportsPath returns the directory the TUI writes exported files to, next
+// to the config file.
+func ExportsPath() (string, error) {
+	p, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(p), "exports"), nil
+}
+
 // LoadConfig loads configuration from disk or returns default.
 func LoadConfig() (Config, error) {
 	p, err := ConfigPath()
@@ -62,7 +117,7 @@ func LoadConfig() (Config, error) {
 	if c.LangPref == "" {
 		c.LangPref = "en"
 	}
-	return c, nil
+	return c.WithProfile(c.ProfileID), nil
 }
 
 // SaveConfig saves configuration to disk, creating dirs as needed.
@@ -71,6 +126,14 @@ func SaveConfig(c Config) error {
 	if err != nil {
 		return err
 	}
+	if c.ProfileID != "" {
+		profiles := make(map[string]ProfileSettings, len(c.Profiles)+1)
+		for id, ps := range c.Profiles {
+			profiles[id] = ps
+		}
+		profiles[c.ProfileID] = ProfileSettings{LangPref: c.LangPref, QuestionsPerSession: c.QuestionsPerSession}
+		c.Profiles = profiles
+	}
 	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
 		return fmt.Errorf("failed to create config dir: %w", err)
 	}
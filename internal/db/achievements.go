@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ListAchievementUnlocks returns when the player unlocked each achievement,
+// keyed by achievement ID.
+func ListAchievementUnlocks(ctx context.Context, playerID string) (map[string]time.Time, error) {
+	if dbConn == nil {
+		return nil, nil
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT achievement_id, unlocked_at FROM player_achievements WHERE player_id = ?
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query achievements: %w", err)
+	}
+	defer rows.Close()
+
+	unlocks := map[string]time.Time{}
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+		unlocks[id] = at
+	}
+	return unlocks, rows.Err()
+}
+
+// UnlockAchievement records that the player unlocked achievementID at at.
+// It reports false when the achievement was already unlocked, keeping the
+// original timestamp.
+func UnlockAchievement(ctx context.Context, playerID, achievementID string, at time.Time) (bool, error) {
+	if dbConn == nil {
+		return false, nil
+	}
+	if playerID == "" {
+		return false, fmt.Errorf("player ID is required")
+	}
+	res, err := dbConn.ExecContext(ctx, `
+        INSERT OR IGNORE INTO player_achievements (player_id, achievement_id, unlocked_at) VALUES (?, ?, ?)
+    `, playerID, achievementID, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlock achievement: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to unlock achievement: %w", err)
+	}
+	return n > 0, nil
+}
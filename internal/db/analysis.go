@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AnalysisRecord is a saved weakness analysis. WeakPoints and StrengthPoints
+// hold the insights as JSON.
+type AnalysisRecord struct {
+	ID             string
+	PlayerID       string
+	AnalyzedRange  int // number of sessions the analysis looked at
+	WeakPoints     string
+	StrengthPoints string
+	Recommendation string
+	GeneratedAt    time.Time
+}
+
+// SaveAnalysis stores an analysis, filling in its ID and time when empty.
+func SaveAnalysis(ctx context.Context, rec AnalysisRecord) error {
+	if dbConn == nil {
+		return nil
+	}
+	if rec.PlayerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	if rec.ID == "" {
+		rec.ID = newSessionID()
+	}
+	if rec.GeneratedAt.IsZero() {
+		rec.GeneratedAt = time.Now()
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        INSERT INTO analysis (id, player_id, analyzed_range, weak_points, strength_points, recommendation, generated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `, rec.ID, rec.PlayerID, rec.AnalyzedRange, rec.WeakPoints, rec.StrengthPoints, rec.Recommendation, rec.GeneratedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save analysis: %w", err)
+	}
+	return nil
+}
+
+// ListAnalyses returns the player's saved analyses, newest first.
+func ListAnalyses(ctx context.Context, playerID string) ([]AnalysisRecord, error) {
+	if dbConn == nil {
+		return nil, nil
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT id, player_id, COALESCE(analyzed_range, 0), COALESCE(weak_points, ''), COALESCE(strength_points, ''),
+            COALESCE(recommendation, ''), generated_at
+        FROM analysis
+        WHERE player_id = ?
+        ORDER BY generated_at DESC
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analyses: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AnalysisRecord
+	for rows.Next() {
+		var rec AnalysisRecord
+		if err := rows.Scan(
+			&rec.ID, &rec.PlayerID, &rec.AnalyzedRange, &rec.WeakPoints, &rec.StrengthPoints,
+			&rec.Recommendation, &rec.GeneratedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan analysis: %w", err)
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BossVictory summarizes a player's wins against one boss.
+type BossVictory struct {
+	Victories  int
+	FirstWonAt time.Time
+	LastWonAt  time.Time
+}
+
+// ListBossVictories returns the player's wins keyed by boss ID.
+func ListBossVictories(ctx context.Context, playerID string) (map[string]BossVictory, error) {
+	if dbConn == nil {
+		return nil, nil
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT boss_id, victories, first_won_at, last_won_at FROM boss_victories WHERE player_id = ?
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query boss victories: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]BossVictory{}
+	for rows.Next() {
+		var id string
+		var v BossVictory
+		if err := rows.Scan(&id, &v.Victories, &v.FirstWonAt, &v.LastWonAt); err != nil {
+			return nil, fmt.Errorf("failed to scan boss victory: %w", err)
+		}
+		out[id] = v
+	}
+	return out, rows.Err()
+}
+
+// RecordBossVictory counts a win against bossID at at and returns the
+// player's total wins against that boss, including this one.
+func RecordBossVictory(ctx context.Context, playerID, bossID string, at time.Time) (int, error) {
+	if dbConn == nil {
+		return 0, nil
+	}
+	if playerID == "" {
+		return 0, fmt.Errorf("player ID is required")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO boss_victories (player_id, boss_id, victories, first_won_at, last_won_at) VALUES (?, ?, 1, ?, ?)
+        ON CONFLICT(player_id, boss_id) DO UPDATE SET victories = victories + 1, last_won_at = excluded.last_won_at
+    `, playerID, bossID, at, at); err != nil {
+		return 0, fmt.Errorf("failed to record boss victory: %w", err)
+	}
+	var n int
+	if err := tx.QueryRowContext(ctx, `
+        SELECT victories FROM boss_victories WHERE player_id = ? AND boss_id = ?
+    `, playerID, bossID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to read boss victories: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit boss victory: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// EquipmentRecord is an entry of the equipment catalog.
+type EquipmentRecord struct {
+	ID          string
+	Slot        string
+	Name        string
+	EffectType  string
+	EffectValue float64
+	TargetMode  string // a mode name or "all"
+	Price       int
+}
+
+// ErrEquipmentNotOwned is returned when equipping an item the player does
+// not own.
+var ErrEquipmentNotOwned = errors.New("equipment not owned")
+
+const equipmentColumns = `e.id, e.slot, e.name, COALESCE(e.effect_type, ''), COALESCE(e.effect_value, 0), COALESCE(e.target_mode, 'all'), COALESCE(e.price, 0)`
+
+// ListEquipment returns the whole catalog ordered by slot and price.
+func ListEquipment(ctx context.Context) ([]EquipmentRecord, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return queryEquipment(ctx, `
+        SELECT `+equipmentColumns+`
+        FROM equipment e
+        ORDER BY e.slot, e.price, e.name
+    `)
+}
+
+// ListOwnedEquipment returns the items the player owns, including the free
+// starter gear.
+func ListOwnedEquipment(ctx context.Context, playerID string) ([]EquipmentRecord, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return queryEquipment(ctx, `
+        SELECT `+equipmentColumns+`
+        FROM equipment e
+        LEFT JOIN player_equipment pe ON pe.equipment_id = e.id AND pe.player_id = ?
+        WHERE e.price = 0 OR pe.player_id IS NOT NULL
+        ORDER BY e.slot, e.price, e.name
+    `, playerID)
+}
+
+// ListEquippedItems returns the items the player is wearing, one per slot.
+func ListEquippedItems(ctx context.Context, playerID string) ([]EquipmentRecord, error) {
+	if dbConn == nil {
+		return nil, nil
+	}
+	return queryEquipment(ctx, `
+        SELECT `+equipmentColumns+`
+        FROM equipped_items ei
+        JOIN equipment e ON e.id = ei.equipment_id
+        WHERE ei.player_id = ?
+        ORDER BY ei.slot
+    `, playerID)
+}
+
+// AddEquipment puts an item into the player's inventory.
+func AddEquipment(ctx context.Context, playerID, equipmentID string) error {
+	if dbConn == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        INSERT OR IGNORE INTO player_equipment (player_id, equipment_id) VALUES (?, ?)
+    `, playerID, equipmentID)
+	if err != nil {
+		return fmt.Errorf("failed to add equipment: %w", err)
+	}
+	return nil
+}
+
+// EquipItem wears an owned item in its slot, replacing whatever was there.
+func EquipItem(ctx context.Context, playerID, equipmentID string) error {
+	if dbConn == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if playerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	var slot string
+	err := dbConn.QueryRowContext(ctx, `
+        SELECT e.slot
+        FROM equipment e
+        LEFT JOIN player_equipment pe ON pe.equipment_id = e.id AND pe.player_id = ?
+        WHERE e.id = ? AND (e.price = 0 OR pe.player_id IS NOT NULL)
+    `, playerID, equipmentID).Scan(&slot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrEquipmentNotOwned
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up equipment: %w", err)
+	}
+	_, err = dbConn.ExecContext(ctx, `
+        INSERT INTO equipped_items (player_id, slot, equipment_id) VALUES (?, ?, ?)
+        ON CONFLICT(player_id, slot) DO UPDATE SET equipment_id = excluded.equipment_id
+    `, playerID, slot, equipmentID)
+	if err != nil {
+		return fmt.Errorf("failed to equip item: %w", err)
+	}
+	return nil
+}
+
+// UnequipSlot empties a slot.
+func UnequipSlot(ctx context.Context, playerID, slot string) error {
+	if dbConn == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        DELETE FROM equipped_items WHERE player_id = ? AND slot = ?
+    `, playerID, slot)
+	if err != nil {
+		return fmt.Errorf("failed to unequip slot: %w", err)
+	}
+	return nil
+}
+
+func queryEquipment(ctx context.Context, query string, args ...any) ([]EquipmentRecord, error) {
+	rows, err := dbConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equipment: %w", err)
+	}
+	defer rows.Close()
+
+	var items []EquipmentRecord
+	for rows.Next() {
+		var rec EquipmentRecord
+		if err := rows.Scan(&rec.ID, &rec.Slot, &rec.Name, &rec.EffectType, &rec.EffectValue, &rec.TargetMode, &rec.Price); err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		items = append(items, rec)
+	}
+	return items, rows.Err()
+}
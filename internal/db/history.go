@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"io/fs"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
@@ -28,93 +29,33 @@ type SessionRecord struct {
 	DefenseDelta  float64
 	Fainted       bool
 	LeveledUp     bool
+	Review        bool // a review run of due items rather than a full question set
+	// QuestionCount is the number of questions in the session's log, read
+	// back from session_items; 0 for sessions saved without one.
+	QuestionCount int
 }
 
 var dbConn *sql.DB
 
-// InitDB initializes the SQLite database connection and creates tables.
+// InitDB opens the SQLite database and applies pending schema migrations.
 func InitDB(dataSourceName string) error {
 	var err error
 	dbConn, err = sql.Open("sqlite3", dataSourceName)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
+	// SQLite allows a single writer; background prefetches share one connection
+	// with the UI instead of failing with "database is locked".
+	dbConn.SetMaxOpenConns(1)
 
-	schema := `
-  CREATE TABLE IF NOT EXISTS profiles (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        class TEXT NOT NULL,
-        level INTEGER NOT NULL,
-        exp INTEGER NOT NULL,
-        next_level_exp INTEGER NOT NULL,
-        hp INTEGER NOT NULL,
-        max_hp INTEGER NOT NULL,
-        attack INTEGER NOT NULL,
-        defense REAL NOT NULL,
-        combo INTEGER NOT NULL,
-        streak_days INTEGER NOT NULL,
-        gold INTEGER NOT NULL,
-        exp_boost REAL NOT NULL DEFAULT 0,
-        damage_reduction REAL NOT NULL DEFAULT 0,
-        ui_language TEXT,
-        explanation_language TEXT,
-        problem_language TEXT,
-        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-    );
-
-
-	CREATE TABLE IF NOT EXISTS sessions (
-		id TEXT PRIMARY KEY,
-		player_id TEXT NOT NULL,
-		mode TEXT NOT NULL,
-		started_at TIMESTAMP,
-		ended_at TIMESTAMP,
-		question_set_id TEXT,
-		correct_count INTEGER,
-		best_combo INTEGER,
-		exp_gained INTEGER,
-		exp_lost INTEGER,
-		hp_delta INTEGER,
-		gold_delta INTEGER,
-		defense_delta REAL,
-		fainted INTEGER,
-		leveled_up INTEGER,
-		FOREIGN KEY(player_id) REFERENCES profiles(id)
-	);
-
-	CREATE TABLE IF NOT EXISTS equipment (
-		id TEXT PRIMARY KEY,
-		slot TEXT NOT NULL,
-		name TEXT NOT NULL,
-		effect_type TEXT,
-		effect_value REAL,
-		target_mode TEXT,
-		price INTEGER
-	);
-
-	CREATE TABLE IF NOT EXISTS analysis (
-		id TEXT PRIMARY KEY,
-		player_id TEXT NOT NULL,
-		analyzed_range INTEGER,
-		weak_points TEXT,
-		strength_points TEXT,
-		recommendation TEXT,
-		generated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		FOREIGN KEY(player_id) REFERENCES profiles(id)
-	);
-	`
-	_, err = dbConn.Exec(schema)
+	sub, err := fs.Sub(migrationFS, "migrations")
 	if err != nil {
-		return fmt.Errorf("failed to create tables: %w", err)
+		return fmt.Errorf("failed to open migrations: %w", err)
 	}
-	if err := ensureProfileColumn("exp_boost", "exp_boost REAL NOT NULL DEFAULT 0"); err != nil {
+	if err := adoptLegacy(dbConn); err != nil {
 		return err
 	}
-	if err := ensureProfileColumn("damage_reduction", "damage_reduction REAL NOT NULL DEFAULT 0"); err != nil {
-		return err
-	}
-	return nil
+	return migrate(dbConn, sub)
 }
 
 // SaveSession persists a session record to the database.
@@ -126,8 +67,8 @@ func SaveSession(ctx context.Context, rec SessionRecord) error {
 		return nil
 	}
 	stmt, err := dbConn.PrepareContext(ctx, `
-            INSERT INTO sessions (id, player_id, mode, started_at, ended_at, question_set_id, correct_count, best_combo, exp_gained, exp_lost, hp_delta, gold_delta, defense_delta, fainted, leveled_up)
-            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+            INSERT INTO sessions (id, player_id, mode, started_at, ended_at, question_set_id, correct_count, best_combo, exp_gained, exp_lost, hp_delta, gold_delta, defense_delta, fainted, leveled_up, review)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         `)
 
 	if err != nil {
@@ -138,7 +79,7 @@ func SaveSession(ctx context.Context, rec SessionRecord) error {
 	_, err = stmt.ExecContext(ctx,
 		rec.ID, rec.PlayerID, rec.Mode, rec.StartedAt, rec.EndedAt, rec.QuestionSetID,
 		rec.CorrectCount, rec.BestCombo, rec.ExpGained, rec.ExpLost, rec.HPDelta,
-		rec.GoldDelta, rec.DefenseDelta, boolToInt(rec.Fainted), boolToInt(rec.LeveledUp),
+		rec.GoldDelta, rec.DefenseDelta, boolToInt(rec.Fainted), boolToInt(rec.LeveledUp), boolToInt(rec.Review),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to execute statement: %w", err)
@@ -148,13 +89,50 @@ func SaveSession(ctx context.Context, rec SessionRecord) error {
 
 // ListSessions fetches recent session records for a player.
 func ListSessions(ctx context.Context, playerID string, limit int) ([]SessionRecord, error) {
+	return ListSessionsPage(ctx, playerID, SessionFilter{}, limit, 0)
+}
+
+// SessionFilter narrows a player's sessions. Zero fields match everything.
+// Times are compared as instants, whatever zone they were stored in.
+type SessionFilter struct {
+	Mode  string
+	Since time.Time // sessions that ended at or after Since
+	Until time.Time // sessions that ended before Until
+}
+
+func (f SessionFilter) where(playerID string) (string, []any) {
+	where := `WHERE player_id = ?`
+	args := []any{playerID}
+	if f.Mode != "" {
+		where += ` AND mode = ?`
+		args = append(args, f.Mode)
+	}
+	if !f.Since.IsZero() {
+		where += ` AND julianday(ended_at) >= julianday(?)`
+		args = append(args, f.Since)
+	}
+	if !f.Until.IsZero() {
+		where += ` AND julianday(ended_at) < julianday(?)`
+		args = append(args, f.Until)
+	}
+	return where, args
+}
+
+// ListSessionsPage returns up to limit of the player's sessions matching f,
+// newest first, skipping the first offset. A negative limit returns all.
+func ListSessionsPage(ctx context.Context, playerID string, f SessionFilter, limit, offset int) ([]SessionRecord, error) {
+	if dbConn == nil {
+		return nil, nil
+	}
+	where, args := f.where(playerID)
 	rows, err := dbConn.QueryContext(ctx, `
-        SELECT id, player_id, mode, started_at, ended_at, question_set_id, correct_count, best_combo, exp_gained, exp_lost, hp_delta, gold_delta, defense_delta, fainted, leveled_up
+        SELECT id, player_id, mode, started_at, ended_at, question_set_id, correct_count, best_combo, exp_gained, exp_lost, hp_delta, gold_delta, defense_delta, fainted, leveled_up, review,
+            (SELECT COUNT(*) FROM session_items WHERE session_items.session_id = sessions.id)
         FROM sessions
-        WHERE player_id = ?
-        ORDER BY ended_at DESC
-        LIMIT ?
-    `, playerID, limit)
+        `+where+`
+        ORDER BY julianday(ended_at) DESC
+        LIMIT ? OFFSET ?
+    `, append(args, limit, offset)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sessions: %w", err)
 	}
@@ -163,66 +141,55 @@ func ListSessions(ctx context.Context, playerID string, limit int) ([]SessionRec
 	var sessions []SessionRecord
 	for rows.Next() {
 		var rec SessionRecord
-		var faintedInt, leveledUpInt int
+		var faintedInt, leveledUpInt, reviewInt int
 		err := rows.Scan(
 			&rec.ID, &rec.PlayerID, &rec.Mode, &rec.StartedAt, &rec.EndedAt, &rec.QuestionSetID,
 			&rec.CorrectCount, &rec.BestCombo, &rec.ExpGained, &rec.ExpLost, &rec.HPDelta,
-			&rec.GoldDelta, &rec.DefenseDelta, &faintedInt, &leveledUpInt,
+			&rec.GoldDelta, &rec.DefenseDelta, &faintedInt, &leveledUpInt, &reviewInt, &rec.QuestionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan session row: %w", err)
 		}
 		rec.Fainted = intToBool(faintedInt)
 		rec.LeveledUp = intToBool(leveledUpInt)
+		rec.Review = intToBool(reviewInt)
 		sessions = append(sessions, rec)
 	}
-	return sessions, nil
+	return sessions, rows.Err()
 }
 
-func ensureProfileColumn(name, definition string) error {
+// CountSessions returns how many of the player's sessions match f.
+func CountSessions(ctx context.Context, playerID string, f SessionFilter) (int, error) {
 	if dbConn == nil {
-		return fmt.Errorf("database not initialized")
+		return 0, nil
 	}
-	exists, err := profileColumnExists(name)
-	if err != nil {
-		return err
-	}
-	if exists {
-		return nil
+	where, args := f.where(playerID)
+	var n int
+	if err := dbConn.QueryRowContext(ctx, `SELECT COUNT(*) FROM sessions `+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count sessions: %w", err)
 	}
-
-	_, err = dbConn.Exec(fmt.Sprintf("ALTER TABLE profiles ADD COLUMN %s", definition))
-	if err != nil {
-		return fmt.Errorf("failed to add column %s: %w", name, err)
-	}
-	return nil
+	return n, nil
 }
 
-func profileColumnExists(name string) (bool, error) {
+// LastSessionEndedAt returns when the player's most recent session ended.
+// ok is false when the player has no sessions yet.
+func LastSessionEndedAt(ctx context.Context, playerID string) (endedAt time.Time, ok bool, err error) {
 	if dbConn == nil {
-		return false, fmt.Errorf("database not initialized")
+		return endedAt, false, nil
 	}
-	rows, err := dbConn.Query(`PRAGMA table_info(profiles)`)
-	if err != nil {
-		return false, fmt.Errorf("failed to query table info: %w", err)
+	err = dbConn.QueryRowContext(ctx, `
+        SELECT ended_at FROM sessions
+        WHERE player_id = ?
+        ORDER BY julianday(ended_at) DESC
+        LIMIT 1
+    `, playerID).Scan(&endedAt)
+	if err == sql.ErrNoRows {
+		return endedAt, false, nil
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var cid int
-		var colName string
-		var colType string
-		var notnull int
-		var dfltValue sql.NullString
-		var pk int
-		if err := rows.Scan(&cid, &colName, &colType, &notnull, &dfltValue, &pk); err != nil {
-			return false, fmt.Errorf("failed to scan table info: %w", err)
-		}
-		if colName == name {
-			return true, nil
-		}
+	if err != nil {
+		return endedAt, false, fmt.Errorf("failed to query last session: %w", err)
 	}
-	return false, nil
+	return endedAt, true, nil
 }
 
 func boolToInt(b bool) int {
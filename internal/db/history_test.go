@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestListSessionsPage_FiltersAndPages(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "history.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	base := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	jst := time.FixedZone("JST", 9*60*60)
+	for i, mode := range []string{"vocab", "grammar", "vocab", "spelling", "vocab"} {
+		ended := base.Add(time.Duration(i) * 24 * time.Hour)
+		if i%2 == 1 {
+			ended = ended.In(jst) // stored with a different offset
+		}
+		rec := SessionRecord{ID: mode + string(rune('a'+i)), PlayerID: "p1", Mode: mode, StartedAt: ended, EndedAt: ended}
+		if err := SaveSession(ctx, rec); err != nil {
+			t.Fatalf("SaveSession error: %v", err)
+		}
+	}
+	if err := SaveSession(ctx, SessionRecord{ID: "other", PlayerID: "p2", Mode: "vocab", EndedAt: base}); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+
+	vocab := SessionFilter{Mode: "vocab"}
+	if n, err := CountSessions(ctx, "p1", vocab); err != nil || n != 3 {
+		t.Fatalf("expected 3 vocab sessions, got %d (%v)", n, err)
+	}
+	page, err := ListSessionsPage(ctx, "p1", vocab, 2, 2)
+	if err != nil || len(page) != 1 || !page[0].EndedAt.Equal(base) {
+		t.Fatalf("expected the oldest vocab session on page 2, got %+v (%v)", page, err)
+	}
+
+	window := SessionFilter{Since: base.Add(24 * time.Hour), Until: base.Add(3 * 24 * time.Hour)}
+	got, err := ListSessionsPage(ctx, "p1", window, -1, 0)
+	if err != nil || len(got) != 2 || got[0].Mode != "vocab" || got[1].Mode != "grammar" {
+		t.Fatalf("expected days 2 and 3 newest first, got %+v (%v)", got, err)
+	}
+	if n, err := CountSessions(ctx, "p1", window); err != nil || n != 2 {
+		t.Fatalf("expected 2 sessions in the window, got %d (%v)", n, err)
+	}
+}
+
+func TestListSessionsPage_CountsLoggedQuestions(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "history_count.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Now()
+	for _, id := range []string{"logged", "unlogged"} {
+		if err := SaveSession(ctx, SessionRecord{ID: id, PlayerID: "p1", Mode: "vocab", StartedAt: now, EndedAt: now}); err != nil {
+			t.Fatalf("SaveSession error: %v", err)
+		}
+	}
+	items := []SessionItem{
+		{SessionID: "logged", PlayerID: "p1", Mode: "vocab", Seq: 0, Prompt: "a"},
+		{SessionID: "logged", PlayerID: "p1", Mode: "vocab", Seq: 1, Prompt: "b"},
+	}
+	if err := SaveSessionItems(ctx, items); err != nil {
+		t.Fatalf("SaveSessionItems error: %v", err)
+	}
+	got, err := ListSessionsPage(ctx, "p1", SessionFilter{}, -1, 0)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %+v (%v)", got, err)
+	}
+	counts := map[string]int{got[0].ID: got[0].QuestionCount, got[1].ID: got[1].QuestionCount}
+	if counts["logged"] != 2 || counts["unlogged"] != 0 {
+		t.Fatalf("unexpected question counts %v", counts)
+	}
+}
+
+func TestLastSessionEndedAt_ComparesTimesAcrossOffsets(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "history.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	if _, ok, err := LastSessionEndedAt(ctx, "p1"); err != nil || ok {
+		t.Fatalf("expected no last session yet, got %v (%v)", ok, err)
+	}
+	// 10:00 in Tokyo is 01:00 UTC, earlier than the session at 03:00 UTC
+	// even though its text sorts later.
+	jst := time.FixedZone("JST", 9*60*60)
+	earlier := time.Date(2026, 4, 10, 10, 0, 0, 0, jst)
+	latest := time.Date(2026, 4, 10, 3, 0, 0, 0, time.UTC)
+	for id, ended := range map[string]time.Time{"a": earlier, "b": latest} {
+		if err := SaveSession(ctx, SessionRecord{ID: id, PlayerID: "p1", Mode: "vocab", StartedAt: ended, EndedAt: ended}); err != nil {
+			t.Fatalf("SaveSession error: %v", err)
+		}
+	}
+	got, ok, err := LastSessionEndedAt(ctx, "p1")
+	if err != nil || !ok || !got.Equal(latest) {
+		t.Fatalf("expected the session at %v, got %v (%v)", latest, got, err)
+	}
+}
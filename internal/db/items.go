@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ItemCount returns how many of itemID the player owns.
+func ItemCount(ctx context.Context, playerID, itemID string) (int, error) {
+	if dbConn == nil {
+		return 0, nil
+	}
+	var n int
+	err := dbConn.QueryRowContext(ctx, `
+        SELECT quantity FROM player_items WHERE player_id = ? AND item_id = ?
+    `, playerID, itemID).Scan(&n)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query item count: %w", err)
+	}
+	return n, nil
+}
+
+// AddItems adjusts the player's quantity of itemID by delta. Negative deltas
+// consume items; the quantity never drops below zero.
+func AddItems(ctx context.Context, playerID, itemID string, delta int) error {
+	if dbConn == nil {
+		return nil
+	}
+	if playerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        INSERT INTO player_items (player_id, item_id, quantity)
+        VALUES (?, ?, MAX(?, 0))
+        ON CONFLICT(player_id, item_id) DO UPDATE SET
+            quantity = MAX(quantity + ?, 0)
+    `, playerID, itemID, delta, delta)
+	if err != nil {
+		return fmt.Errorf("failed to update items: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migrations are numbered SQL files, e.g. migrations/0002_question_cache.sql.
+// Add a new file for every schema change; never edit one that has shipped.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations reads the *.sql files in the root of fsys ordered by their
+// numeric prefix.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	migrations := make([]migration, 0, len(paths))
+	seen := map[int]string{}
+	for _, p := range paths {
+		name := strings.TrimSuffix(path.Base(p), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a positive version number", p)
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		seen[version] = name
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", p, err)
+		}
+		migrations = append(migrations, migration{version: version, name: name, sql: string(raw)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// migrate applies the migrations in fsys that conn has not recorded in
+// schema_migrations. Each migration runs in its own transaction together
+// with its schema_migrations row, so a failing migration leaves no trace.
+func migrate(conn *sql.DB, fsys fs.FS) error {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Exec(`
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	applied, err := appliedVersions(conn)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := applyMigration(conn, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyProfileColumns were added to profiles with ALTER TABLE before
+// versioned migrations, so databases from that time may lack them.
+var legacyProfileColumns = []struct{ name, definition string }{
+	{"exp_boost", "exp_boost REAL NOT NULL DEFAULT 0"},
+	{"damage_reduction", "damage_reduction REAL NOT NULL DEFAULT 0"},
+}
+
+// adoptLegacy brings a database written before versioned migrations up to
+// the schema 0001_initial expects. Its profiles predate class selection and
+// all carry the old default class, so they become Novices rather than keep
+// a specialty bonus nobody chose. It does nothing once schema_migrations
+// exists or when there is no profiles table yet.
+func adoptLegacy(conn *sql.DB) error {
+	versioned, err := tableExists(conn, "schema_migrations")
+	if err != nil || versioned {
+		return err
+	}
+	legacy, err := tableExists(conn, "profiles")
+	if err != nil || !legacy {
+		return err
+	}
+	for _, c := range legacyProfileColumns {
+		exists, err := columnExists(conn, "profiles", c.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := conn.Exec("ALTER TABLE profiles ADD COLUMN " + c.definition); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", c.name, err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE profiles SET class = 'Novice' WHERE class = 'Vocabulary Warrior'`); err != nil {
+		return fmt.Errorf("failed to reset legacy classes: %w", err)
+	}
+	return nil
+}
+
+func tableExists(conn *sql.DB, table string) (bool, error) {
+	var n int
+	err := conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up table %s: %w", table, err)
+	}
+	return n > 0, nil
+}
+
+func columnExists(conn *sql.DB, table, column string) (bool, error) {
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("failed to query table info: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, fmt.Errorf("failed to scan table info: %w", err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+func appliedVersions(conn *sql.DB) (map[int]bool, error) {
+	rows, err := conn.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+func applyMigration(conn *sql.DB, m migration) error {
+	tx, err := conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", m.name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.sql); err != nil {
+		return fmt.Errorf("migration %s failed: %w", m.name, err)
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.version, m.name); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", m.name, err)
+	}
+	return nil
+}
+
+// SchemaVersion returns the highest applied migration version.
+func SchemaVersion() (int, error) {
+	if dbConn == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	var v sql.NullInt64
+	if err := dbConn.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&v); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(v.Int64), nil
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInitDB_AppliesMigrationsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "migrate.sqlite")
+	if err := InitDB(path); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	sub, err := fs.Sub(migrationFS, "migrations")
+	if err != nil {
+		t.Fatalf("fs.Sub error: %v", err)
+	}
+	migrations, err := loadMigrations(sub)
+	if err != nil {
+		t.Fatalf("loadMigrations error: %v", err)
+	}
+	want := migrations[len(migrations)-1].version
+	if v, err := SchemaVersion(); err != nil || v != want {
+		t.Fatalf("expected schema version %d, got %d (%v)", want, v, err)
+	}
+
+	// Reopening must not re-run anything.
+	if err := InitDB(path); err != nil {
+		t.Fatalf("second InitDB error: %v", err)
+	}
+	var n int
+	if err := dbConn.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&n); err != nil || n != len(migrations) {
+		t.Fatalf("expected %d recorded migrations, got %d (%v)", len(migrations), n, err)
+	}
+	if err := SaveProfile(context.Background(), ProfileRecord{ID: "p1", Name: "Aoi", Class: "Grammar Mage", ExpBoost: 0.1}); err != nil {
+		t.Fatalf("SaveProfile on the migrated schema: %v", err)
+	}
+}
+
+func TestInitDB_AdoptsLegacyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.sqlite")
+	legacy, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open error: %v", err)
+	}
+	// A database written before versioned migrations: no schema_migrations,
+	// some tables already populated, and profiles from before exp_boost and
+	// damage_reduction were added.
+	_, err = legacy.Exec(`
+	CREATE TABLE profiles (
+		id TEXT PRIMARY KEY, name TEXT NOT NULL, class TEXT NOT NULL, level INTEGER NOT NULL,
+		exp INTEGER NOT NULL, next_level_exp INTEGER NOT NULL, hp INTEGER NOT NULL, max_hp INTEGER NOT NULL,
+		attack INTEGER NOT NULL, defense REAL NOT NULL, combo INTEGER NOT NULL, streak_days INTEGER NOT NULL,
+		gold INTEGER NOT NULL, ui_language TEXT, explanation_language TEXT, problem_language TEXT,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	INSERT INTO profiles (id, name, class, level, exp, next_level_exp, hp, max_hp, attack, defense, combo, streak_days, gold)
+	VALUES ('old', 'Takuya', 'Vocabulary Warrior', 7, 12, 60, 120, 120, 22, 6, 0, 3, 40);`)
+	legacy.Close()
+	if err != nil {
+		t.Fatalf("legacy schema error: %v", err)
+	}
+
+	if err := InitDB(path); err != nil {
+		t.Fatalf("InitDB on a legacy database: %v", err)
+	}
+	rec, err := LoadProfile(context.Background(), "old")
+	if err != nil || rec.Level != 7 || rec.Gold != 40 {
+		t.Fatalf("expected the legacy profile to survive, got %+v (%v)", rec, err)
+	}
+	if rec.Class != "Novice" {
+		t.Fatalf("expected the legacy default class to become Novice, got %q", rec.Class)
+	}
+	rec.ExpBoost = 0.1
+	if err := SaveProfile(context.Background(), rec); err != nil {
+		t.Fatalf("SaveProfile on the adopted schema: %v", err)
+	}
+	if _, err := ListSessionItems(context.Background(), "none"); err != nil {
+		t.Fatalf("expected later tables to be created, got %v", err)
+	}
+}
+
+func TestMigrate_RollsBackFailedMigration(t *testing.T) {
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "broken.sqlite"))
+	if err != nil {
+		t.Fatalf("open error: %v", err)
+	}
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	fsys := fstest.MapFS{
+		"0001_first.sql":  {Data: []byte(`CREATE TABLE first (id INTEGER);`)},
+		"0002_broken.sql": {Data: []byte(`CREATE TABLE second (id INTEGER); CREATE TABLE oops (;`)},
+	}
+	if err := migrate(conn, fsys); err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+	var n int
+	conn.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&n)
+	if n != 1 {
+		t.Fatalf("expected only the first migration recorded, got %d", n)
+	}
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM second`).Scan(&n); err == nil {
+		t.Fatal("expected the failed migration's tables to be rolled back")
+	}
+
+	fsys["0002_broken.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE second (id INTEGER);`)}
+	if err := migrate(conn, fsys); err != nil {
+		t.Fatalf("expected the fixed migration to apply, got %v", err)
+	}
+}
+
+func TestLoadMigrations_RejectsBadNames(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"unnumbered": {"initial.sql": {Data: []byte(`SELECT 1;`)}},
+		"duplicate": {
+			"0001_a.sql": {Data: []byte(`SELECT 1;`)},
+			"001_b.sql":  {Data: []byte(`SELECT 1;`)},
+		},
+	} {
+		if _, err := loadMigrations(fsys); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
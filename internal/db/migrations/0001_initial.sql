@@ -1,3 +1,5 @@
+-- Core tables. IF NOT EXISTS lets databases created before versioned
+-- migrations adopt this schema unchanged.
 CREATE TABLE IF NOT EXISTS profiles (
     id TEXT PRIMARY KEY,
     name TEXT NOT NULL,
@@ -12,6 +14,8 @@ CREATE TABLE IF NOT EXISTS profiles (
     combo INTEGER NOT NULL,
     streak_days INTEGER NOT NULL,
     gold INTEGER NOT NULL,
+    exp_boost REAL NOT NULL DEFAULT 0,
+    damage_reduction REAL NOT NULL DEFAULT 0,
     ui_language TEXT,
     explanation_language TEXT,
     problem_language TEXT,
@@ -0,0 +1,14 @@
+-- Validated question sets per mode/language/count, served when the backend
+-- is unreachable.
+CREATE TABLE IF NOT EXISTS question_cache (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    mode TEXT NOT NULL,
+    lang TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    served INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    served_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_cache_lookup ON question_cache(mode, lang, count, served);
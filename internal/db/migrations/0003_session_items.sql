@@ -0,0 +1,20 @@
+-- One row per answered question, linked to its session.
+CREATE TABLE IF NOT EXISTS session_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id TEXT NOT NULL,
+    player_id TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    prompt TEXT NOT NULL,
+    options TEXT,
+    chosen TEXT,
+    correct_answer TEXT,
+    explanation TEXT,
+    correct INTEGER NOT NULL,
+    outcome TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(session_id) REFERENCES sessions(id),
+    FOREIGN KEY(player_id) REFERENCES profiles(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_items_session ON session_items(session_id, seq);
@@ -0,0 +1,17 @@
+-- SM-2 review queue of missed items.
+CREATE TABLE IF NOT EXISTS review_items (
+    player_id TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    item_key TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    ease REAL NOT NULL,
+    interval_days INTEGER NOT NULL,
+    repetitions INTEGER NOT NULL,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMP NOT NULL,
+    last_reviewed_at TIMESTAMP,
+    PRIMARY KEY(player_id, mode, item_key),
+    FOREIGN KEY(player_id) REFERENCES profiles(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_items_due ON review_items(player_id, mode, due_at);
@@ -0,0 +1,8 @@
+-- Consumable items owned by each player, e.g. streak freezes.
+CREATE TABLE IF NOT EXISTS player_items (
+    player_id TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY(player_id, item_id),
+    FOREIGN KEY(player_id) REFERENCES profiles(id)
+);
@@ -0,0 +1,32 @@
+-- Equipment catalog, owned items and the item worn in each slot. Items with
+-- price 0 are starter gear that every player owns.
+INSERT OR IGNORE INTO equipment (id, slot, name, effect_type, effect_value, target_mode, price) VALUES
+    ('wooden_sword', 'weapon', 'Wooden Sword', 'exp_boost', 0.05, 'vocab', 0),
+    ('iron_sword', 'weapon', 'Iron Sword', 'exp_boost', 0.10, 'vocab', 120),
+    ('grammar_staff', 'weapon', 'Grammarian''s Staff', 'exp_boost', 0.10, 'grammar', 120),
+    ('scribe_quill', 'weapon', 'Scribe''s Quill', 'exp_boost', 0.10, 'spelling', 120),
+    ('cloth_tunic', 'armor', 'Cloth Tunic', 'damage_reduction', 0.05, 'all', 0),
+    ('chain_mail', 'armor', 'Chain Mail', 'damage_reduction', 0.15, 'all', 200),
+    ('echo_cloak', 'armor', 'Echo Cloak', 'damage_reduction', 0.20, 'listening', 150),
+    ('ring_of_focus', 'ring', 'Ring of Focus', 'exp_boost', 0.05, 'all', 180),
+    ('ring_of_clarity', 'ring', 'Ring of Clarity', 'exp_boost', 0.15, 'listening', 150),
+    ('lucky_charm', 'charm', 'Lucky Charm', 'damage_reduction', 0.10, 'grammar', 100),
+    ('bard_charm', 'charm', 'Bard''s Charm', 'exp_boost', 0.15, 'tavern', 150);
+
+CREATE TABLE IF NOT EXISTS player_equipment (
+    player_id TEXT NOT NULL,
+    equipment_id TEXT NOT NULL,
+    acquired_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(player_id, equipment_id),
+    FOREIGN KEY(player_id) REFERENCES profiles(id),
+    FOREIGN KEY(equipment_id) REFERENCES equipment(id)
+);
+
+CREATE TABLE IF NOT EXISTS equipped_items (
+    player_id TEXT NOT NULL,
+    slot TEXT NOT NULL,
+    equipment_id TEXT NOT NULL,
+    PRIMARY KEY(player_id, slot),
+    FOREIGN KEY(player_id) REFERENCES profiles(id),
+    FOREIGN KEY(equipment_id) REFERENCES equipment(id)
+);
@@ -0,0 +1,8 @@
+-- Achievements each player has unlocked and when.
+CREATE TABLE IF NOT EXISTS player_achievements (
+    player_id TEXT NOT NULL,
+    achievement_id TEXT NOT NULL,
+    unlocked_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(player_id, achievement_id),
+    FOREIGN KEY(player_id) REFERENCES profiles(id)
+);
@@ -0,0 +1,2 @@
+-- Response time per answered question, in milliseconds; 0 when not measured.
+ALTER TABLE session_items ADD COLUMN latency_ms INTEGER NOT NULL DEFAULT 0;
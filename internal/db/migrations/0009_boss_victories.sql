@@ -0,0 +1,10 @@
+-- Boss battles each player has won, how often, and when first and last.
+CREATE TABLE IF NOT EXISTS boss_victories (
+    player_id TEXT NOT NULL,
+    boss_id TEXT NOT NULL,
+    victories INTEGER NOT NULL DEFAULT 0,
+    first_won_at TIMESTAMP NOT NULL,
+    last_won_at TIMESTAMP NOT NULL,
+    PRIMARY KEY(player_id, boss_id),
+    FOREIGN KEY(player_id) REFERENCES profiles(id)
+);
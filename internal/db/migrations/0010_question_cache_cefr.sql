@@ -0,0 +1,3 @@
+-- Cached question sets are only interchangeable at the same CEFR target.
+-- Sets cached before targets existed keep an empty level.
+ALTER TABLE question_cache ADD COLUMN cefr TEXT NOT NULL DEFAULT '';
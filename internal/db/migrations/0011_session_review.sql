@@ -0,0 +1,3 @@
+-- 1 for review runs, which serve due review items instead of a full
+-- question set.
+ALTER TABLE sessions ADD COLUMN review INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,336 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PlayerData is everything stored for one player, as moved by backups.
+type PlayerData struct {
+	Profile       ProfileRecord
+	Sessions      []SessionRecord
+	SessionItems  []SessionItem
+	ReviewItems   []ReviewItemRecord
+	Analyses      []AnalysisRecord
+	Items         map[string]int       // quantity per consumable item ID
+	Equipment     []string             // IDs of bought equipment; starter gear is implied
+	Equipped      map[string]string    // equipment ID worn per slot
+	Achievements  map[string]time.Time // unlock time per achievement ID
+	BossVictories map[string]BossVictory
+}
+
+// LoadPlayerData reads every row that belongs to playerID.
+func LoadPlayerData(ctx context.Context, playerID string) (PlayerData, error) {
+	var data PlayerData
+	var err error
+	if data.Profile, err = LoadProfile(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Sessions, err = ListSessions(ctx, playerID, -1); err != nil {
+		return data, err
+	}
+	if data.SessionItems, err = ListPlayerSessionItems(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.ReviewItems, err = listPlayerReviewItems(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Analyses, err = ListAnalyses(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Items, err = listPlayerItems(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Equipment, err = listBoughtEquipment(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Equipped, err = listEquippedSlots(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.Achievements, err = ListAchievementUnlocks(ctx, playerID); err != nil {
+		return data, err
+	}
+	if data.BossVictories, err = ListBossVictories(ctx, playerID); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ImportPlayerData writes data in one transaction and returns how many of
+// its sessions were new. With replace the player's existing rows are
+// deleted first. Otherwise data is merged into them: the more recently
+// updated profile wins, sessions, analyses and bought equipment are added
+// when missing, item counts and boss records keep the larger value, review
+// cards keep the more recently reviewed one, and existing achievements and
+// worn equipment stay as they are.
+func ImportPlayerData(ctx context.Context, data PlayerData, replace bool) (int, error) {
+	if dbConn == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	playerID := data.Profile.ID
+	if playerID == "" {
+		return 0, fmt.Errorf("player ID is required")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replace {
+		if err := deletePlayerRows(ctx, tx, playerID); err != nil {
+			return 0, fmt.Errorf("failed to clear player data: %w", err)
+		}
+	}
+	if err := importProfile(ctx, tx, data.Profile); err != nil {
+		return 0, err
+	}
+	added, err := importSessions(ctx, tx, playerID, data.Sessions, data.SessionItems)
+	if err != nil {
+		return 0, err
+	}
+	if err := importReviewItems(ctx, tx, playerID, data.ReviewItems); err != nil {
+		return 0, err
+	}
+	for _, a := range data.Analyses {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT OR IGNORE INTO analysis (id, player_id, analyzed_range, weak_points, strength_points, recommendation, generated_at)
+            VALUES (?, ?, ?, ?, ?, ?, ?)
+        `, a.ID, playerID, a.AnalyzedRange, a.WeakPoints, a.StrengthPoints, a.Recommendation, a.GeneratedAt); err != nil {
+			return 0, fmt.Errorf("failed to import analysis: %w", err)
+		}
+	}
+	for id, n := range data.Items {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO player_items (player_id, item_id, quantity) VALUES (?, ?, ?)
+            ON CONFLICT(player_id, item_id) DO UPDATE SET quantity = MAX(quantity, excluded.quantity)
+        `, playerID, id, n); err != nil {
+			return 0, fmt.Errorf("failed to import items: %w", err)
+		}
+	}
+	for _, id := range data.Equipment {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT OR IGNORE INTO player_equipment (player_id, equipment_id) VALUES (?, ?)
+        `, playerID, id); err != nil {
+			return 0, fmt.Errorf("failed to import equipment: %w", err)
+		}
+	}
+	for slot, id := range data.Equipped {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT OR IGNORE INTO equipped_items (player_id, slot, equipment_id) VALUES (?, ?, ?)
+        `, playerID, slot, id); err != nil {
+			return 0, fmt.Errorf("failed to import equipped items: %w", err)
+		}
+	}
+	for id, at := range data.Achievements {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT OR IGNORE INTO player_achievements (player_id, achievement_id, unlocked_at) VALUES (?, ?, ?)
+        `, playerID, id, at); err != nil {
+			return 0, fmt.Errorf("failed to import achievements: %w", err)
+		}
+	}
+	for id, v := range data.BossVictories {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO boss_victories (player_id, boss_id, victories, first_won_at, last_won_at) VALUES (?, ?, ?, ?, ?)
+            ON CONFLICT(player_id, boss_id) DO UPDATE SET
+                victories = excluded.victories,
+                first_won_at = excluded.first_won_at,
+                last_won_at = excluded.last_won_at
+            WHERE excluded.victories > boss_victories.victories
+        `, playerID, id, v.Victories, v.FirstWonAt, v.LastWonAt); err != nil {
+			return 0, fmt.Errorf("failed to import boss victories: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit player data: %w", err)
+	}
+	return added, nil
+}
+
+// importProfile writes rec unless the stored profile was updated later.
+func importProfile(ctx context.Context, tx *sql.Tx, rec ProfileRecord) error {
+	var stored time.Time
+	err := tx.QueryRowContext(ctx, `SELECT updated_at FROM profiles WHERE id = ?`, rec.ID).Scan(&stored)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to look up profile: %w", err)
+	case stored.After(rec.UpdatedAt):
+		return nil
+	}
+	if rec.UpdatedAt.IsZero() {
+		rec.UpdatedAt = time.Now()
+	}
+	_, err = tx.ExecContext(ctx, `
+        INSERT OR REPLACE INTO profiles (id, name, class, level, exp, next_level_exp, hp, max_hp, attack, defense, combo, streak_days, gold, exp_boost, damage_reduction, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `,
+		rec.ID, rec.Name, rec.Class, rec.Level, rec.Exp, rec.NextLevelExp, rec.HP, rec.MaxHP,
+		rec.Attack, rec.Defense, rec.Combo, rec.StreakDays, rec.Gold, rec.ExpBoost, rec.DamageReduction,
+		rec.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to import profile: %w", err)
+	}
+	return nil
+}
+
+// importSessions adds the sessions that are not stored yet together with
+// their question logs.
+func importSessions(ctx context.Context, tx *sql.Tx, playerID string, sessions []SessionRecord, items []SessionItem) (int, error) {
+	added := map[string]bool{}
+	for _, s := range sessions {
+		res, err := tx.ExecContext(ctx, `
+            INSERT OR IGNORE INTO sessions (id, player_id, mode, started_at, ended_at, question_set_id, correct_count, best_combo, exp_gained, exp_lost, hp_delta, gold_delta, defense_delta, fainted, leveled_up, review)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        `,
+			s.ID, playerID, s.Mode, s.StartedAt, s.EndedAt, s.QuestionSetID,
+			s.CorrectCount, s.BestCombo, s.ExpGained, s.ExpLost, s.HPDelta,
+			s.GoldDelta, s.DefenseDelta, boolToInt(s.Fainted), boolToInt(s.LeveledUp), boolToInt(s.Review),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import session: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n > 0 {
+			added[s.ID] = true
+		}
+	}
+	for _, it := range items {
+		if !added[it.SessionID] {
+			continue
+		}
+		opts, err := json.Marshal(it.Options)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode options: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO session_items (session_id, player_id, mode, seq, prompt, options, chosen, correct_answer, explanation, correct, outcome, latency_ms, created_at)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        `,
+			it.SessionID, playerID, it.Mode, it.Seq, it.Prompt, string(opts), it.Chosen,
+			it.CorrectAnswer, it.Explanation, boolToInt(it.Correct), it.Outcome, it.Latency.Milliseconds(), it.CreatedAt,
+		); err != nil {
+			return 0, fmt.Errorf("failed to import session item: %w", err)
+		}
+	}
+	return len(added), nil
+}
+
+// importReviewItems writes each card unless the stored one was reviewed
+// more recently.
+func importReviewItems(ctx context.Context, tx *sql.Tx, playerID string, cards []ReviewItemRecord) error {
+	for _, rec := range cards {
+		stored, err := scanReviewItem(tx.QueryRowContext(ctx, `
+            SELECT player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at
+            FROM review_items
+            WHERE player_id = ? AND mode = ? AND item_key = ?
+        `, playerID, rec.Mode, rec.ItemKey))
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return err
+		case !rec.LastReviewedAt.After(stored.LastReviewedAt):
+			continue
+		}
+		var last any
+		if !rec.LastReviewedAt.IsZero() {
+			last = rec.LastReviewedAt.UTC()
+		}
+		if _, err := tx.ExecContext(ctx, `
+            INSERT OR REPLACE INTO review_items (player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        `, playerID, rec.Mode, rec.ItemKey, rec.Payload, rec.Ease, rec.IntervalDays, rec.Repetitions,
+			rec.Lapses, rec.DueAt.UTC(), last); err != nil {
+			return fmt.Errorf("failed to import review item: %w", err)
+		}
+	}
+	return nil
+}
+
+func listPlayerReviewItems(ctx context.Context, playerID string) ([]ReviewItemRecord, error) {
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at
+        FROM review_items
+        WHERE player_id = ?
+        ORDER BY mode, item_key
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ReviewItemRecord
+	for rows.Next() {
+		rec, err := scanReviewItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	return items, rows.Err()
+}
+
+func listPlayerItems(ctx context.Context, playerID string) (map[string]int, error) {
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT item_id, quantity FROM player_items WHERE player_id = ?
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]int{}
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		out[id] = n
+	}
+	return out, rows.Err()
+}
+
+func listBoughtEquipment(ctx context.Context, playerID string) ([]string, error) {
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT equipment_id FROM player_equipment WHERE player_id = ? ORDER BY equipment_id
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equipment: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+func listEquippedSlots(ctx context.Context, playerID string) (map[string]string, error) {
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT slot, equipment_id FROM equipped_items WHERE player_id = ?
+    `, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equipped items: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]string{}
+	for rows.Next() {
+		var slot, id string
+		if err := rows.Scan(&slot, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan equipped item: %w", err)
+		}
+		out[slot] = id
+	}
+	return out, rows.Err()
+}
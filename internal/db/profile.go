@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"time"
 )
@@ -39,6 +41,15 @@ func CurrentProfileID() string {
 	return currentProfileID
 }
 
+// NewProfileID returns a random ID for a new profile.
+func NewProfileID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("player-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf[:])
+}
+
 // LoadProfile loads saved stats for the given player ID.
 func LoadProfile(ctx context.Context, playerID string) (ProfileRecord, error) {
 	var rec ProfileRecord
@@ -104,3 +115,102 @@ func SaveProfile(ctx context.Context, rec ProfileRecord) error {
 	}
 	return nil
 }
+
+// ListProfiles returns every saved profile, most recently played first.
+func ListProfiles(ctx context.Context) ([]ProfileRecord, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT id, name, class, level, exp, next_level_exp, hp, max_hp, attack, defense, combo, streak_days, gold, exp_boost, damage_reduction, updated_at
+        FROM profiles
+        ORDER BY updated_at DESC, name ASC
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []ProfileRecord
+	for rows.Next() {
+		var rec ProfileRecord
+		err := rows.Scan(
+			&rec.ID, &rec.Name, &rec.Class, &rec.Level, &rec.Exp, &rec.NextLevelExp, &rec.HP, &rec.MaxHP,
+			&rec.Attack, &rec.Defense, &rec.Combo, &rec.StreakDays, &rec.Gold, &rec.ExpBoost,
+			&rec.DamageReduction, &rec.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, rec)
+	}
+	return profiles, rows.Err()
+}
+
+// DeleteProfile removes a profile together with its history, analyses,
+// review queue, items, equipment and achievements.
+func DeleteProfile(ctx context.Context, playerID string) error {
+	if dbConn == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if playerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deletePlayerRows(ctx, tx, playerID); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ResetProfile replaces the profile rec.ID with rec and removes everything
+// else that belongs to it: history, items, equipment, achievements, boss
+// victories, review cards and analyses.
+func ResetProfile(ctx context.Context, rec ProfileRecord) error {
+	if dbConn == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if rec.ID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deletePlayerRows(ctx, tx, rec.ID); err != nil {
+		return fmt.Errorf("failed to reset profile: %w", err)
+	}
+	rec.UpdatedAt = time.Time{}
+	if err := importProfile(ctx, tx, rec); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deletePlayerRows removes the profile row and every row that belongs to it.
+func deletePlayerRows(ctx context.Context, tx *sql.Tx, playerID string) error {
+	for _, q := range []string{
+		`DELETE FROM session_items WHERE player_id = ?`,
+		`DELETE FROM sessions WHERE player_id = ?`,
+		`DELETE FROM review_items WHERE player_id = ?`,
+		`DELETE FROM analysis WHERE player_id = ?`,
+		`DELETE FROM player_items WHERE player_id = ?`,
+		`DELETE FROM equipped_items WHERE player_id = ?`,
+		`DELETE FROM player_equipment WHERE player_id = ?`,
+		`DELETE FROM player_achievements WHERE player_id = ?`,
+		`DELETE FROM boss_victories WHERE player_id = ?`,
+		`DELETE FROM profiles WHERE id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, q, playerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// QuestionSetKey identifies interchangeable cached question sets.
+type QuestionSetKey struct {
+	Mode  string
+	Lang  string
+	Count int
+	CEFR  string // difficulty target; empty for untargeted sets
+}
+
+// SaveQuestionSet stores a validated question payload. Prefetched sets are
+// saved unserved; sets that were fetched on demand are saved as already served
+// so they are only reused when the backend is unavailable.
+func SaveQuestionSet(ctx context.Context, key QuestionSetKey, content []byte, served bool) error {
+	if dbConn == nil {
+		return nil
+	}
+	var servedAt any
+	if served {
+		servedAt = time.Now()
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        INSERT INTO question_cache (mode, lang, count, cefr, content, served, created_at, served_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `, key.Mode, key.Lang, key.Count, key.CEFR, string(content), boolToInt(served), time.Now(), servedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save question set: %w", err)
+	}
+	return nil
+}
+
+// TakeQuestionSet returns the oldest unserved set for key and marks it served.
+// ok is false when nothing is waiting.
+func TakeQuestionSet(ctx context.Context, key QuestionSetKey) (content []byte, ok bool, err error) {
+	if dbConn == nil {
+		return nil, false, nil
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	var text string
+	err = tx.QueryRowContext(ctx, `
+        SELECT id, content FROM question_cache
+        WHERE mode = ? AND lang = ? AND count = ? AND cefr = ? AND served = 0
+        ORDER BY created_at ASC, id ASC
+        LIMIT 1
+    `, key.Mode, key.Lang, key.Count, key.CEFR).Scan(&id, &text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query question cache: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE question_cache SET served = 1, served_at = ? WHERE id = ?`, time.Now(), id); err != nil {
+		return nil, false, fmt.Errorf("failed to mark question set served: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit question cache: %w", err)
+	}
+	return []byte(text), true, nil
+}
+
+// ReuseQuestionSet returns the least recently served set for key, for replay
+// when no fresh questions can be fetched. ok is false when the cache is empty.
+func ReuseQuestionSet(ctx context.Context, key QuestionSetKey) (content []byte, ok bool, err error) {
+	if dbConn == nil {
+		return nil, false, nil
+	}
+	var id int64
+	var text string
+	err = dbConn.QueryRowContext(ctx, `
+        SELECT id, content FROM question_cache
+        WHERE mode = ? AND lang = ? AND count = ? AND cefr = ? AND served = 1
+        ORDER BY served_at ASC, id ASC
+        LIMIT 1
+    `, key.Mode, key.Lang, key.Count, key.CEFR).Scan(&id, &text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query question cache: %w", err)
+	}
+	if _, err := dbConn.ExecContext(ctx, `UPDATE question_cache SET served_at = ? WHERE id = ?`, time.Now(), id); err != nil {
+		return nil, false, fmt.Errorf("failed to update question cache: %w", err)
+	}
+	return []byte(text), true, nil
+}
+
+// CountUnservedQuestionSets reports how many prefetched sets are waiting for key.
+func CountUnservedQuestionSets(ctx context.Context, key QuestionSetKey) (int, error) {
+	if dbConn == nil {
+		return 0, nil
+	}
+	var n int
+	err := dbConn.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM question_cache
+        WHERE mode = ? AND lang = ? AND count = ? AND cefr = ? AND served = 0
+    `, key.Mode, key.Lang, key.Count, key.CEFR).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count question cache: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReviewItemRecord is a spaced-repetition card for a missed question.
+type ReviewItemRecord struct {
+	PlayerID       string
+	Mode           string
+	ItemKey        string
+	Payload        string // JSON of the question as it was asked
+	Ease           float64
+	IntervalDays   int
+	Repetitions    int
+	Lapses         int
+	DueAt          time.Time
+	LastReviewedAt time.Time // zero until the first review
+}
+
+// GetReviewItem loads the card for key. ok is false when it does not exist.
+func GetReviewItem(ctx context.Context, playerID, mode, key string) (rec ReviewItemRecord, ok bool, err error) {
+	if dbConn == nil {
+		return rec, false, nil
+	}
+	row := dbConn.QueryRowContext(ctx, `
+        SELECT player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at
+        FROM review_items
+        WHERE player_id = ? AND mode = ? AND item_key = ?
+    `, playerID, mode, key)
+	rec, err = scanReviewItem(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rec, false, nil
+	}
+	if err != nil {
+		return rec, false, err
+	}
+	return rec, true, nil
+}
+
+// SaveReviewItem inserts or updates a card.
+func SaveReviewItem(ctx context.Context, rec ReviewItemRecord) error {
+	if dbConn == nil {
+		return nil
+	}
+	if rec.PlayerID == "" {
+		return fmt.Errorf("player ID is required")
+	}
+	var last any
+	if !rec.LastReviewedAt.IsZero() {
+		last = rec.LastReviewedAt.UTC()
+	}
+	_, err := dbConn.ExecContext(ctx, `
+        INSERT INTO review_items (player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT(player_id, mode, item_key) DO UPDATE SET
+            payload = excluded.payload,
+            ease = excluded.ease,
+            interval_days = excluded.interval_days,
+            repetitions = excluded.repetitions,
+            lapses = excluded.lapses,
+            due_at = excluded.due_at,
+            last_reviewed_at = excluded.last_reviewed_at
+    `, rec.PlayerID, rec.Mode, rec.ItemKey, rec.Payload, rec.Ease, rec.IntervalDays, rec.Repetitions,
+		rec.Lapses, rec.DueAt.UTC(), last)
+	if err != nil {
+		return fmt.Errorf("failed to save review item: %w", err)
+	}
+	return nil
+}
+
+// ListDueReviewItems returns up to limit cards for mode that are due at now,
+// most overdue first.
+func ListDueReviewItems(ctx context.Context, playerID, mode string, now time.Time, limit int) ([]ReviewItemRecord, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT player_id, mode, item_key, payload, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at
+        FROM review_items
+        WHERE player_id = ? AND mode = ? AND due_at <= ?
+        ORDER BY due_at ASC
+        LIMIT ?
+    `, playerID, mode, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []ReviewItemRecord
+	for rows.Next() {
+		rec, err := scanReviewItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rec)
+	}
+	return items, rows.Err()
+}
+
+// CountDueReviewItems returns the number of due cards per mode.
+func CountDueReviewItems(ctx context.Context, playerID string, now time.Time) (map[string]int, error) {
+	counts := map[string]int{}
+	if dbConn == nil {
+		return counts, nil
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT mode, COUNT(*) FROM review_items
+        WHERE player_id = ? AND due_at <= ?
+        GROUP BY mode
+    `, playerID, now.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count review items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var mode string
+		var n int
+		if err := rows.Scan(&mode, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan review count: %w", err)
+		}
+		counts[mode] = n
+	}
+	return counts, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReviewItem(row rowScanner) (ReviewItemRecord, error) {
+	var rec ReviewItemRecord
+	var last sql.NullTime
+	err := row.Scan(
+		&rec.PlayerID, &rec.Mode, &rec.ItemKey, &rec.Payload, &rec.Ease, &rec.IntervalDays,
+		&rec.Repetitions, &rec.Lapses, &rec.DueAt, &last,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return rec, err
+		}
+		return rec, fmt.Errorf("failed to scan review item: %w", err)
+	}
+	if last.Valid {
+		rec.LastReviewedAt = last.Time
+	}
+	return rec, nil
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SessionItem is the log of a single question answered during a session.
+type SessionItem struct {
+	ID            int64
+	SessionID     string
+	PlayerID      string
+	Mode          string
+	Seq           int // 0-based position within the session
+	Prompt        string
+	Options       []string
+	Chosen        string
+	CorrectAnswer string
+	Explanation   string
+	Correct       bool
+	Outcome       string        // mode-specific grade such as "perfect"/"near"/"fail"; empty when Correct says it all
+	Latency       time.Duration // time taken to answer; 0 when not measured
+	CreatedAt     time.Time
+}
+
+// SaveSessionItems persists the per-question log of a session in one transaction.
+func SaveSessionItems(ctx context.Context, items []SessionItem) error {
+	if dbConn == nil || len(items) == 0 {
+		return nil
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO session_items (session_id, player_id, mode, seq, prompt, options, chosen, correct_answer, explanation, correct, outcome, latency_ms, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, it := range items {
+		if it.PlayerID == "" {
+			continue
+		}
+		opts, err := json.Marshal(it.Options)
+		if err != nil {
+			return fmt.Errorf("failed to encode options: %w", err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			it.SessionID, it.PlayerID, it.Mode, it.Seq, it.Prompt, string(opts), it.Chosen,
+			it.CorrectAnswer, it.Explanation, boolToInt(it.Correct), it.Outcome, it.Latency.Milliseconds(), now,
+		); err != nil {
+			return fmt.Errorf("failed to save session item: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit session items: %w", err)
+	}
+	return nil
+}
+
+// ListSessionItems returns the question log of a session in answer order.
+func ListSessionItems(ctx context.Context, sessionID string) ([]SessionItem, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	rows, err := dbConn.QueryContext(ctx, `
+        SELECT `+sessionItemColumns+`
+        FROM session_items
+        WHERE session_id = ?
+        ORDER BY seq ASC
+    `, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []SessionItem
+	for rows.Next() {
+		it, err := scanSessionItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// ListPlayerSessionItems returns every question the player answered in the
+// given modes, or in all modes when none are given, oldest first.
+func ListPlayerSessionItems(ctx context.Context, playerID string, modes ...string) ([]SessionItem, error) {
+	if dbConn == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	query := `SELECT ` + sessionItemColumns + ` FROM session_items WHERE player_id = ?`
+	args := []any{playerID}
+	if len(modes) > 0 {
+		query += ` AND mode IN (?` + strings.Repeat(`, ?`, len(modes)-1) + `)`
+		for _, m := range modes {
+			args = append(args, m)
+		}
+	}
+	rows, err := dbConn.QueryContext(ctx, query+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []SessionItem
+	for rows.Next() {
+		it, err := scanSessionItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+const sessionItemColumns = `id, session_id, player_id, mode, seq, prompt, options, chosen, correct_answer, explanation, correct, outcome, latency_ms, created_at`
+
+func scanSessionItem(row rowScanner) (SessionItem, error) {
+	var it SessionItem
+	var opts string
+	var correctInt int
+	var latencyMS int64
+	if err := row.Scan(
+		&it.ID, &it.SessionID, &it.PlayerID, &it.Mode, &it.Seq, &it.Prompt, &opts, &it.Chosen,
+		&it.CorrectAnswer, &it.Explanation, &correctInt, &it.Outcome, &latencyMS, &it.CreatedAt,
+	); err != nil {
+		return it, fmt.Errorf("failed to scan session item row: %w", err)
+	}
+	if opts != "" {
+		if err := json.Unmarshal([]byte(opts), &it.Options); err != nil {
+			return it, fmt.Errorf("failed to decode options: %w", err)
+		}
+	}
+	it.Correct = intToBool(correctInt)
+	it.Latency = time.Duration(latencyMS) * time.Millisecond
+	return it, nil
+}
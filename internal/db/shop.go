@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotEnoughGold is returned when a purchase costs more than the
+	// player's gold.
+	ErrNotEnoughGold = errors.New("not enough gold")
+	// ErrAlreadyOwned is returned when buying equipment the player owns.
+	ErrAlreadyOwned = errors.New("already owned")
+	// ErrItemLimit is returned when a purchase would exceed the item's
+	// carry limit.
+	ErrItemLimit = errors.New("item limit reached")
+)
+
+// BuyEquipment charges the catalog price of equipmentID to the player's gold
+// and adds the item to their inventory in one transaction. It returns the
+// gold left.
+func BuyEquipment(ctx context.Context, playerID, equipmentID string) (int, error) {
+	if dbConn == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin purchase: %w", err)
+	}
+	defer tx.Rollback()
+
+	var price int
+	var owned bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(e.price, 0), pe.player_id IS NOT NULL
+        FROM equipment e
+        LEFT JOIN player_equipment pe ON pe.equipment_id = e.id AND pe.player_id = ?
+        WHERE e.id = ?
+    `, playerID, equipmentID).Scan(&price, &owned)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("unknown equipment %q", equipmentID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up equipment: %w", err)
+	}
+	if owned || price == 0 {
+		return 0, ErrAlreadyOwned
+	}
+
+	gold, err := spendGold(ctx, tx, playerID, price)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO player_equipment (player_id, equipment_id) VALUES (?, ?)
+    `, playerID, equipmentID); err != nil {
+		return 0, fmt.Errorf("failed to add equipment: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+	return gold, nil
+}
+
+// BuyItem charges price to the player's gold and adds one itemID to their
+// items in one transaction. A positive limit caps how many the player may
+// hold. It returns the gold left.
+func BuyItem(ctx context.Context, playerID, itemID string, price, limit int) (int, error) {
+	if dbConn == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin purchase: %w", err)
+	}
+	defer tx.Rollback()
+
+	if limit > 0 {
+		var n int
+		err := tx.QueryRowContext(ctx, `
+            SELECT quantity FROM player_items WHERE player_id = ? AND item_id = ?
+        `, playerID, itemID).Scan(&n)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to query item count: %w", err)
+		}
+		if n >= limit {
+			return 0, ErrItemLimit
+		}
+	}
+
+	gold, err := spendGold(ctx, tx, playerID, price)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO player_items (player_id, item_id, quantity) VALUES (?, ?, 1)
+        ON CONFLICT(player_id, item_id) DO UPDATE SET quantity = quantity + 1
+    `, playerID, itemID); err != nil {
+		return 0, fmt.Errorf("failed to update items: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit purchase: %w", err)
+	}
+	return gold, nil
+}
+
+// spendGold deducts price from the player's gold within tx and returns the
+// gold left.
+func spendGold(ctx context.Context, tx *sql.Tx, playerID string, price int) (int, error) {
+	var gold int
+	err := tx.QueryRowContext(ctx, `SELECT gold FROM profiles WHERE id = ?`, playerID).Scan(&gold)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("profile %q not found", playerID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load gold: %w", err)
+	}
+	if gold < price {
+		return 0, ErrNotEnoughGold
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE profiles SET gold = gold - ? WHERE id = ?`, price, playerID); err != nil {
+		return 0, fmt.Errorf("failed to spend gold: %w", err)
+	}
+	return gold - price, nil
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+// Achievement is a badge unlocked the first time a session meets all of its
+// conditions. Zero-valued conditions are ignored.
+type Achievement struct {
+	ID        string
+	Clear     bool // clear the session (SessionSummary.Cleared)
+	Perfect   bool // answer every question correctly
+	MinCombo  int  // best combo in the session
+	MinStreak int  // daily streak after the session
+	MinLevel  int  // level after the session
+}
+
+// Achievements lists every achievement in display order. Names and
+// descriptions are the i18n keys "achievement_<id>" and
+// "achievement_<id>_desc".
+var Achievements = []Achievement{
+	{ID: "first_clear", Clear: true},
+	{ID: "combo_10", MinCombo: 10},
+	{ID: "perfect_run", Perfect: true},
+	{ID: "streak_7", MinStreak: 7},
+	{ID: "level_10", MinLevel: 10},
+	{ID: "level_25", MinLevel: 25},
+	{ID: "level_50", MinLevel: 50},
+	{ID: "level_100", MinLevel: 100},
+}
+
+// Met reports whether a session ending with stats and summary satisfies a.
+func (a Achievement) Met(stats Stats, summary SessionSummary) bool {
+	if a.Clear && !summary.Cleared {
+		return false
+	}
+	if a.Perfect && (summary.Fainted || summary.Review || summary.Total == 0 || summary.Correct < summary.Total) {
+		return false
+	}
+	return summary.BestCombo >= a.MinCombo &&
+		stats.Streak >= a.MinStreak &&
+		stats.Level >= a.MinLevel
+}
+
+// AchievementStatus pairs an achievement with when the player unlocked it.
+type AchievementStatus struct {
+	Achievement
+	UnlockedAt time.Time // zero while locked
+}
+
+// Unlocked reports whether the achievement has been unlocked.
+func (s AchievementStatus) Unlocked() bool {
+	return !s.UnlockedAt.IsZero()
+}
+
+// AchievementProgress returns every achievement with the active profile's
+// unlock times.
+func AchievementProgress(ctx context.Context) ([]AchievementStatus, error) {
+	unlocks, err := db.ListAchievementUnlocks(ctx, db.CurrentProfileID())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AchievementStatus, len(Achievements))
+	for i, a := range Achievements {
+		out[i] = AchievementStatus{Achievement: a, UnlockedAt: unlocks[a.ID]}
+	}
+	return out, nil
+}
+
+// unlockAchievements evaluates every rule against a finished session and
+// records the newly met ones for the active profile, returning them.
+// Failures are logged and skip the achievement.
+func unlockAchievements(ctx context.Context, stats Stats, summary SessionSummary, now time.Time) []Achievement {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return nil
+	}
+	var unlocked []Achievement
+	for _, a := range Achievements {
+		if !a.Met(stats, summary) {
+			continue
+		}
+		isNew, err := db.UnlockAchievement(ctx, playerID, a.ID, now)
+		if err != nil {
+			log.Printf("failed to unlock achievement %s: %v", a.ID, err)
+			continue
+		}
+		if isNew {
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
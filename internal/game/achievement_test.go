@@ -0,0 +1,128 @@
+package game
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestAchievement_Met(t *testing.T) {
+	byID := map[string]Achievement{}
+	for _, a := range Achievements {
+		byID[a.ID] = a
+	}
+	stats := DefaultStats()
+	cases := []struct {
+		id      string
+		stats   func(Stats) Stats
+		summary SessionSummary
+		want    bool
+	}{
+		{"first_clear", nil, SessionSummary{Total: 5, Correct: 2, Cleared: true}, true},
+		{"first_clear", nil, SessionSummary{Total: 5, Correct: 2, Fainted: true}, false},
+		{"first_clear", nil, SessionSummary{Mode: "boss", Total: 5, Correct: 2}, false},
+		{"perfect_run", nil, SessionSummary{Total: 5, Correct: 5}, true},
+		{"perfect_run", nil, SessionSummary{Total: 5, Correct: 4}, false},
+		{"perfect_run", nil, SessionSummary{}, false},
+		{"combo_10", nil, SessionSummary{BestCombo: 10}, true},
+		{"combo_10", nil, SessionSummary{BestCombo: 9}, false},
+		{"streak_7", func(s Stats) Stats { s.Streak = 7; return s }, SessionSummary{}, true},
+		{"streak_7", func(s Stats) Stats { s.Streak = 6; return s }, SessionSummary{}, false},
+		{"level_10", func(s Stats) Stats { s.Level = 12; return s }, SessionSummary{Fainted: true}, true},
+		{"level_25", func(s Stats) Stats { s.Level = 24; return s }, SessionSummary{}, false},
+	}
+	for _, c := range cases {
+		s := stats
+		if c.stats != nil {
+			s = c.stats(s)
+		}
+		if got := byID[c.id].Met(s, c.summary); got != c.want {
+			t.Errorf("%s.Met(level %d, streak %d, %+v) = %v, want %v", c.id, s.Level, s.Streak, c.summary, got, c.want)
+		}
+	}
+}
+
+func TestAchievements_UnlockOnceAfterSession(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "achievements.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	stats := DefaultStats()
+	answers := []VocabAnswer{{Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
+	stats, summary, _ := RunVocabSession(ctx, stats, answers)
+	got := map[string]bool{}
+	for _, a := range summary.Unlocked {
+		got[a.ID] = true
+	}
+	if len(got) != 2 || !got["first_clear"] || !got["perfect_run"] {
+		t.Fatalf("expected first clear and perfect run, got %+v", summary.Unlocked)
+	}
+
+	// The combo carries over to 10; nothing else unlocks twice.
+	_, again, _ := RunVocabSession(ctx, stats, answers)
+	if len(again.Unlocked) != 1 || again.Unlocked[0].ID != "combo_10" {
+		t.Fatalf("expected only the combo achievement, got %+v", again.Unlocked)
+	}
+	got["combo_10"] = true
+
+	progress, err := AchievementProgress(ctx)
+	if err != nil || len(progress) != len(Achievements) {
+		t.Fatalf("AchievementProgress = %d entries (%v)", len(progress), err)
+	}
+	for _, p := range progress {
+		if p.Unlocked() != got[p.ID] {
+			t.Fatalf("unexpected unlock state for %s: %v", p.ID, p.UnlockedAt)
+		}
+	}
+}
+
+func TestAchievements_FirstClearNeedsARealClear(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "first_clear.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	// A tavern talk never faints, but failing every turn is no clear.
+	failed := make([]TavernAnswer, 5)
+	for i := range failed {
+		failed[i].Outcome = OutcomeFail
+	}
+	_, summary, _ := RunTavernSession(ctx, DefaultStats(), failed)
+	if summary.Cleared || len(summary.Unlocked) != 0 {
+		t.Fatalf("expected a failed tavern talk to unlock nothing, got %+v", summary.Unlocked)
+	}
+
+	// Three hits of the four needed, and two misses the player survives: the
+	// battle is lost without fainting.
+	boss := Bosses[0]
+	stats := DefaultStats()
+	stats.Level = boss.MinLevel
+	stats.Next = ExpToNext(stats.Level)
+	stats.MaxHP = MaxHPForLevel(stats.Level)
+	stats.HP = stats.MaxHP
+	_, summary, err := RunBossSession(ctx, stats, boss, 5, []BossAnswer{{Correct: true}, {}, {Correct: true}, {}, {Correct: true}})
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if summary.Fainted || summary.BossDefeated || summary.Cleared {
+		t.Fatalf("expected a lost battle without fainting, got %+v", summary)
+	}
+	for _, a := range summary.Unlocked {
+		if a.ID == "first_clear" {
+			t.Fatal("expected a lost boss battle not to unlock first clear")
+		}
+	}
+
+	passed := []TavernAnswer{{Outcome: OutcomeSuccess}, {Outcome: OutcomeNormal}, {Outcome: OutcomeFail}}
+	_, summary, _ = RunTavernSession(ctx, DefaultStats(), passed)
+	if !summary.Cleared || len(summary.Unlocked) != 1 || summary.Unlocked[0].ID != "first_clear" {
+		t.Fatalf("expected a passed tavern talk to unlock first clear, got %+v", summary.Unlocked)
+	}
+}
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+// ErrBossLocked is returned when the player is below a boss's level.
+var ErrBossLocked = errors.New("boss is locked")
+
+const (
+	// bossHitShare is the share of a boss battle's questions the player must
+	// answer correctly to bring the boss down.
+	bossHitShare = 0.7
+	// bossBaseExp is the per-question base EXP of a boss battle.
+	bossBaseExp = 5
+	// bossRematchShare is the share of the boss reward paid for wins after
+	// the first.
+	bossRematchShare = 0.25
+)
+
+// Boss guards the boundary into a tier and unlocks once the player reaches
+// it. Names are the i18n keys "boss_<id>".
+type Boss struct {
+	ID         string
+	Tier       int // tier whose first level unlocks the boss
+	MinLevel   int
+	HP         int
+	ExpReward  int // paid in full on the first victory
+	GoldReward int
+}
+
+// Bosses lists one boss per tier boundary of TierForLevel, in order.
+var Bosses = []Boss{
+	{ID: "stone_golem", Tier: 2, MinLevel: 20, HP: 800, ExpReward: 250, GoldReward: 300},
+	{ID: "riddle_sphinx", Tier: 3, MinLevel: 50, HP: 1500, ExpReward: 550, GoldReward: 500},
+	{ID: "lich_scholar", Tier: 4, MinLevel: 100, HP: 2500, ExpReward: 1000, GoldReward: 800},
+	{ID: "abyss_kraken", Tier: 5, MinLevel: 200, HP: 4000, ExpReward: 3000, GoldReward: 1200},
+	{ID: "word_dragon", Tier: 6, MinLevel: 400, HP: 6000, ExpReward: 7000, GoldReward: 2000},
+}
+
+// BossByID looks up a boss.
+func BossByID(id string) (Boss, bool) {
+	for _, b := range Bosses {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return Boss{}, false
+}
+
+// Unlocked reports whether a player with stats may challenge b.
+func (b Boss) Unlocked(stats Stats) bool {
+	return stats.Level >= b.MinLevel
+}
+
+// HitDamage is the damage one correct answer deals to b in a battle of total
+// questions.
+func (b Boss) HitDamage(total int) int {
+	hits := int(math.Ceil(float64(total) * bossHitShare))
+	if hits < 1 {
+		hits = 1
+	}
+	return int(math.Ceil(float64(b.HP) / float64(hits)))
+}
+
+// BossStatus pairs a boss with the active profile's progress against it.
+type BossStatus struct {
+	Boss
+	Unlocked  bool
+	Victories int
+}
+
+// BossProgress returns every boss with whether stats unlocks it and the
+// active profile's wins against it.
+func BossProgress(ctx context.Context, stats Stats) ([]BossStatus, error) {
+	wins, err := db.ListBossVictories(ctx, db.CurrentProfileID())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]BossStatus, len(Bosses))
+	for i, b := range Bosses {
+		out[i] = BossStatus{Boss: b, Unlocked: b.Unlocked(stats), Victories: wins[b.ID].Victories}
+	}
+	return out, nil
+}
+
+// BossAnswer represents correctness per boss battle question.
+type BossAnswer struct {
+	Correct bool
+	Item    QuestionLog
+}
+
+// RunBossSession applies boss battle rules. Each correct answer hits the boss
+// for HitDamage and each miss hurts the player as in other modes. The battle
+// ends when the boss or the player falls; a victory pays the boss reward,
+// reduced to bossRematchShare after the first win, and is recorded for the
+// active profile. questions is the number of questions the battle was fought
+// with, which sets the hit damage and the allowed misses even when the battle
+// ended before the last of them was answered.
+func RunBossSession(ctx context.Context, stats Stats, boss Boss, questions int, answers []BossAnswer) (Stats, SessionSummary, error) {
+	if !boss.Unlocked(stats) {
+		return stats, SessionSummary{}, ErrBossLocked
+	}
+	startedAt := time.Now()
+	summary := SessionSummary{Mode: "boss", BossID: boss.ID, BossHP: boss.HP}
+	before := stats
+	combo := stats.Combo
+	bestCombo := combo
+	stats.MaxHP = MaxHPForLevel(stats.Level)
+	N := max(questions, len(answers))
+	M := AllowedMisses(N)
+	gear := EquipmentEffects(ctx, summary.Mode)
+	dmg := gear.Damage(DamagePerMiss(stats.MaxHP, M))
+	hit := boss.HitDamage(N)
+	_, tierMul := TierForLevel(boss.MinLevel)
+
+	sumCorrectExp := 0
+	hpDelta := 0
+	fainted := false
+	for i, a := range answers {
+		if a.Correct {
+			combo = comboAfterCorrect(combo, a.Item.Latency)
+			if combo > bestCombo {
+				bestCombo = combo
+			}
+			if IsFast(a.Item.Latency) {
+				summary.FastAnswers++
+			}
+			sumCorrectExp += speedExp(QExpFor(bossBaseExp, tierMul, false), a.Item.Latency)
+			summary.Correct++
+			summary.BossHP = max(summary.BossHP-hit, 0)
+			if summary.BossHP == 0 {
+				answers = answers[:i+1]
+				break
+			}
+			continue
+		}
+		combo = ResetCombo(Stats{Combo: combo}).Combo
+		hpDelta -= dmg
+		stats.HP -= dmg
+		if stats.HP <= 0 {
+			stats.HP = 0
+			if healed, heal := drinkPotion(ctx, stats); heal > 0 {
+				stats = healed
+				hpDelta += heal
+				summary.PotionsUsed++
+				continue
+			}
+			fainted = true
+			answers = answers[:i+1]
+			break
+		}
+	}
+	stats.Combo = combo
+	summary.Total = len(answers)
+	summary.BossDefeated = summary.BossHP == 0 && !fainted
+	summary.Cleared = summary.BossDefeated
+
+	endedAt := time.Now()
+	var sessionExp int
+	if summary.BossDefeated {
+		summary.BossVictories = recordBossVictory(ctx, boss, endedAt)
+		share := 1.0
+		if summary.BossVictories > 1 {
+			share = bossRematchShare
+		}
+		sessionExp = sumCorrectExp + int(math.Round(float64(boss.ExpReward)*share))
+		summary.GoldDelta = int(math.Round(float64(boss.GoldReward) * share))
+		stats = AddGold(stats, summary.GoldDelta)
+	} else {
+		sessionExp = SessionExpFail(sumCorrectExp, 0.40)
+	}
+	stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
+	if fainted {
+		stats = ApplyFaintPenalty(stats)
+	}
+
+	items := make([]db.SessionItem, 0, len(answers))
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Correct, ""))
+	}
+	summary.ExpDelta = sessionExp
+	summary.HPDelta = hpDelta
+	summary.BestCombo = bestCombo
+	summary.Fainted = fainted
+	summary.LeveledUp = LeveledUp(before, stats)
+
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
+	rec := db.NewSessionRecord("boss", startedAt, endedAt)
+	rec.CorrectCount = summary.Correct
+	rec.BestCombo = summary.BestCombo
+	rec.ExpGained = summary.ExpDelta
+	rec.HPDelta = summary.HPDelta
+	rec.GoldDelta = summary.GoldDelta
+	rec.Fainted = summary.Fainted
+	rec.LeveledUp = summary.LeveledUp
+	recordSession(ctx, rec, items, stats)
+	return stats, summary, nil
+}
+
+// recordBossVictory stores a win against boss for the active profile and
+// returns the profile's wins against it. Without a profile, or when the
+// database fails, the win counts as the first.
+func recordBossVictory(ctx context.Context, boss Boss, at time.Time) int {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return 1
+	}
+	n, err := db.RecordBossVictory(ctx, playerID, boss.ID, at)
+	if err != nil {
+		log.Printf("failed to record boss victory: %v", err)
+		return 1
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
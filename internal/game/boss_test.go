@@ -0,0 +1,118 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestBosses_GuardTierBoundaries(t *testing.T) {
+	if len(Bosses) != 5 {
+		t.Fatalf("expected a boss for each of the 5 tier boundaries, got %d", len(Bosses))
+	}
+	for _, b := range Bosses {
+		if tier, _ := TierForLevel(b.MinLevel); tier != b.Tier {
+			t.Errorf("%s: level %d is tier %d, want %d", b.ID, b.MinLevel, tier, b.Tier)
+		}
+		if tier, _ := TierForLevel(b.MinLevel - 1); tier != b.Tier-1 {
+			t.Errorf("%s: level %d should still be tier %d, got %d", b.ID, b.MinLevel-1, b.Tier-1, tier)
+		}
+	}
+}
+
+func TestRunBossSession_VictoryIsRecordedAndRewarded(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "boss.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	boss := Bosses[0]
+	stats := DefaultStats()
+	if _, _, err := RunBossSession(ctx, stats, boss, 1, []BossAnswer{{Correct: true}}); !errors.Is(err, ErrBossLocked) {
+		t.Fatalf("expected a level 1 player to be locked out, got %v", err)
+	}
+
+	stats.Level = boss.MinLevel
+	stats.Next = ExpToNext(stats.Level)
+	answers := make([]BossAnswer, 10)
+	for i := range answers {
+		answers[i].Correct = true
+	}
+	after, summary, err := RunBossSession(ctx, stats, boss, len(answers), answers)
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if !summary.BossDefeated || summary.BossHP != 0 || summary.BossVictories != 1 {
+		t.Fatalf("expected a first victory, got %+v", summary)
+	}
+	// 7 of 10 correct answers bring the boss down and end the battle.
+	if summary.Correct != 7 || summary.Total != 7 {
+		t.Fatalf("expected the battle to end after 7 hits, got %d/%d", summary.Correct, summary.Total)
+	}
+	if summary.GoldDelta != boss.GoldReward || after.Gold != boss.GoldReward || summary.ExpDelta <= boss.ExpReward {
+		t.Fatalf("expected the full boss reward, got %+v", summary)
+	}
+
+	_, again, _ := RunBossSession(ctx, stats, boss, len(answers), answers)
+	if again.BossVictories != 2 || again.GoldDelta >= boss.GoldReward {
+		t.Fatalf("expected a reduced rematch reward, got %+v", again)
+	}
+	progress, err := BossProgress(ctx, stats)
+	if err != nil {
+		t.Fatalf("BossProgress error: %v", err)
+	}
+	if !progress[0].Unlocked || progress[0].Victories != 2 || progress[1].Unlocked || progress[1].Victories != 0 {
+		t.Fatalf("unexpected boss progress: %+v", progress[:2])
+	}
+}
+
+func TestRunBossSession_MissesLoseTheBattle(t *testing.T) {
+	boss := Bosses[0]
+	stats := DefaultStats()
+	stats.Level = boss.MinLevel
+	answers := make([]BossAnswer, 10)
+	_, summary, err := RunBossSession(context.Background(), stats, boss, len(answers), answers)
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if summary.BossDefeated || !summary.Fainted || summary.BossHP != boss.HP || summary.GoldDelta != 0 {
+		t.Fatalf("expected the player to fall to an untouched boss, got %+v", summary)
+	}
+}
+
+func TestRunBossSession_EndsBeforeTheLastQuestion(t *testing.T) {
+	boss := Bosses[0]
+	stats := DefaultStats()
+	stats.Level = boss.MinLevel
+	stats.Next = ExpToNext(stats.Level)
+	stats.MaxHP = MaxHPForLevel(stats.Level)
+	stats.HP = stats.MaxHP
+
+	// A battle of 20 questions needs 14 hits; the boss falls on the 17th
+	// answer, with 3 questions left unasked.
+	answers := make([]BossAnswer, 17)
+	for i := range answers {
+		answers[i].Correct = i < 13 || i == 16
+	}
+	_, summary, err := RunBossSession(context.Background(), stats, boss, 20, answers)
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if !summary.BossDefeated || summary.Correct != 14 || summary.Total != 17 {
+		t.Fatalf("expected the boss to fall on the last of 17 answers, got %d/%d %+v", summary.Correct, summary.Total, summary)
+	}
+
+	// 20 questions allow 4 misses, so the player falls on the 5th.
+	_, summary, err = RunBossSession(context.Background(), stats, boss, 20, make([]BossAnswer, 5))
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if !summary.Fainted || summary.Total != 5 {
+		t.Fatalf("expected the player to faint on the 5th miss, got %+v", summary)
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Class is a character class chosen at New Game. Each class specialises in
+// one mode and earns extra EXP there.
+type Class struct {
+	Name     string
+	Mode     string  // specialty mode, e.g. "vocab"
+	ExpBonus float64 // extra EXP fraction earned in Mode
+}
+
+// Classes lists the selectable classes in display order.
+var Classes = []Class{
+	{Name: "Vocabulary Warrior", Mode: "vocab", ExpBonus: 0.20},
+	{Name: "Grammar Mage", Mode: "grammar", ExpBonus: 0.20},
+	{Name: "Conversation Bard", Mode: "tavern", ExpBonus: 0.20},
+}
+
+// NoviceClass is the class of characters that never chose one, such as
+// profiles saved before classes existed. It has no specialty.
+const NoviceClass = "Novice"
+
+// ClassByName returns the class called name.
+func ClassByName(name string) (Class, bool) {
+	for _, c := range Classes {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Class{}, false
+}
+
+// NewCharacter returns the initial stats for a new character with the given
+// name and class.
+func NewCharacter(name, class string) (Stats, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Stats{}, fmt.Errorf("character name is required")
+	}
+	if _, ok := ClassByName(class); !ok {
+		return Stats{}, fmt.Errorf("unknown class %q", class)
+	}
+	stats := DefaultStats()
+	stats.Name = name
+	stats.Class = class
+	return stats, nil
+}
+
+// classExp applies the class bonus to exp earned in mode.
+func classExp(class, mode string, exp int) int {
+	c, ok := ClassByName(class)
+	if !ok || c.Mode != mode {
+		return exp
+	}
+	return int(math.Round(float64(exp) * (1 + c.ExpBonus)))
+}
+
+// gainSessionExp applies the class bonus for mode and the equipment boost,
+// then gains the result, returning the EXP actually earned for the session
+// summary.
+func gainSessionExp(s Stats, mode string, gear Effects, exp int) (Stats, int) {
+	exp = gear.Exp(classExp(s.Class, mode, exp))
+	return GainExp(s, exp), exp
+}
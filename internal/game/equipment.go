@@ -0,0 +1,106 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"tui-english-quest/internal/db"
+)
+
+// Equipment slots in display order.
+var Slots = []string{"weapon", "armor", "ring", "charm"}
+
+const (
+	EffectExpBoost        = "exp_boost"
+	EffectDamageReduction = "damage_reduction"
+	// TargetAll marks equipment that works in every mode.
+	TargetAll = "all"
+	// maxDamageReduction caps the damage reduction gear can provide.
+	maxDamageReduction = 0.5
+)
+
+// ErrNoProfile is returned by equipment operations without an active profile.
+var ErrNoProfile = errors.New("no active profile")
+
+// Effects are the bonuses worn equipment grants in one mode.
+type Effects struct {
+	ExpBoost        float64
+	DamageReduction float64
+}
+
+// EffectsFor sums the effects of items that apply to mode.
+func EffectsFor(items []db.EquipmentRecord, mode string) Effects {
+	var e Effects
+	for _, it := range items {
+		if it.TargetMode != TargetAll && it.TargetMode != mode {
+			continue
+		}
+		switch it.EffectType {
+		case EffectExpBoost:
+			e.ExpBoost += it.EffectValue
+		case EffectDamageReduction:
+			e.DamageReduction += it.EffectValue
+		}
+	}
+	e.DamageReduction = math.Min(e.DamageReduction, maxDamageReduction)
+	return e
+}
+
+// Exp applies the EXP boost to exp.
+func (e Effects) Exp(exp int) int {
+	return int(math.Round(float64(exp) * (1 + e.ExpBoost)))
+}
+
+// Damage applies the damage reduction to dmg.
+func (e Effects) Damage(dmg int) int {
+	return int(math.Round(float64(dmg) * (1 - e.DamageReduction)))
+}
+
+// EquippedItems returns what the active profile is wearing.
+func EquippedItems(ctx context.Context) ([]db.EquipmentRecord, error) {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return nil, nil
+	}
+	return db.ListEquippedItems(ctx, playerID)
+}
+
+// Equip wears an owned item on the active profile.
+func Equip(ctx context.Context, equipmentID string) error {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return ErrNoProfile
+	}
+	return db.EquipItem(ctx, playerID, equipmentID)
+}
+
+// Unequip empties slot on the active profile.
+func Unequip(ctx context.Context, slot string) error {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return ErrNoProfile
+	}
+	return db.UnequipSlot(ctx, playerID, slot)
+}
+
+// EquipmentEffects loads the active profile's gear bonuses for mode.
+// Failures are logged and yield no bonus.
+func EquipmentEffects(ctx context.Context, mode string) Effects {
+	items, err := EquippedItems(ctx)
+	if err != nil {
+		log.Printf("failed to load equipment: %v", err)
+		return Effects{}
+	}
+	return EffectsFor(items, mode)
+}
+
+// OwnedEquipment returns the items the active profile can equip.
+func OwnedEquipment(ctx context.Context) ([]db.EquipmentRecord, error) {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return nil, ErrNoProfile
+	}
+	return db.ListOwnedEquipment(ctx, playerID)
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestEquipment_AppliesPerModeEffects(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "equipment.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	stats := DefaultStats()
+	stats.Class = "Grammar Mage" // keep the class bonus out of vocab
+	answers := []VocabAnswer{{Correct: false}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
+	_, plain, _ := RunVocabSession(ctx, stats, answers)
+
+	if err := Equip(ctx, "iron_sword"); !errors.Is(err, db.ErrEquipmentNotOwned) {
+		t.Fatalf("expected unowned gear to be refused, got %v", err)
+	}
+	for _, id := range []string{"iron_sword", "chain_mail"} {
+		if err := db.AddEquipment(ctx, "player-1", id); err != nil {
+			t.Fatalf("AddEquipment error: %v", err)
+		}
+	}
+	for _, id := range []string{"wooden_sword", "iron_sword", "chain_mail"} {
+		if err := Equip(ctx, id); err != nil {
+			t.Fatalf("Equip(%s) error: %v", id, err)
+		}
+	}
+	items, err := EquippedItems(ctx)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("expected the iron sword to replace the wooden one, got %+v (%v)", items, err)
+	}
+
+	_, geared, _ := RunVocabSession(ctx, stats, answers)
+	// Iron Sword: +10% vocab EXP. Chain Mail: -15% damage in every mode.
+	if geared.ExpDelta != (Effects{ExpBoost: 0.10}).Exp(plain.ExpDelta) {
+		t.Fatalf("expected boosted EXP, got %d (plain %d)", geared.ExpDelta, plain.ExpDelta)
+	}
+	if geared.HPDelta >= 0 || geared.HPDelta <= plain.HPDelta {
+		t.Fatalf("expected reduced damage, got %d (plain %d)", geared.HPDelta, plain.HPDelta)
+	}
+
+	// The sword only helps in vocab.
+	if e := EffectsFor(items, "grammar"); e.ExpBoost != 0 || e.DamageReduction != 0.15 {
+		t.Fatalf("unexpected grammar effects %+v", e)
+	}
+
+	if err := Unequip(ctx, "weapon"); err != nil {
+		t.Fatalf("Unequip error: %v", err)
+	}
+	if items, _ := EquippedItems(ctx); len(items) != 1 || items[0].ID != "chain_mail" {
+		t.Fatalf("expected only the armor to remain, got %+v", items)
+	}
+}
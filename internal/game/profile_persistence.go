@@ -2,6 +2,9 @@ package game
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"log"
 
 	"tui-english-quest/internal/db"
 )
@@ -20,6 +23,52 @@ func SaveStats(ctx context.Context, stats Stats) error {
 	return db.SaveProfile(ctx, rec)
 }
 
+// StartOver replaces the active profile with stats and drops everything the
+// previous character earned, for New Game.
+func StartOver(ctx context.Context, stats Stats) error {
+	profileID := db.CurrentProfileID()
+	if profileID == "" {
+		return nil
+	}
+	rec := profileRecordFromStats(stats)
+	rec.ID = profileID
+	return db.ResetProfile(ctx, rec)
+}
+
+// ActivateProfile makes id the profile used for persistence and returns its
+// stats, seeding default stats when the profile has not been saved yet.
+func ActivateProfile(ctx context.Context, id string) (Stats, error) {
+	rec, err := db.LoadProfile(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		db.SetProfileID(id)
+		stats := DefaultStats()
+		if err := SaveStats(ctx, stats); err != nil {
+			log.Printf("failed to seed profile: %v", err)
+		}
+		return stats, nil
+	}
+	if err != nil {
+		return Stats{}, err
+	}
+	db.SetProfileID(id)
+	return StatsFromProfile(rec), nil
+}
+
+// CreateProfile saves a new character called name of the given class and
+// returns its profile ID. The active profile is left unchanged.
+func CreateProfile(ctx context.Context, name, class string) (string, error) {
+	stats, err := NewCharacter(name, class)
+	if err != nil {
+		return "", err
+	}
+	rec := profileRecordFromStats(stats)
+	rec.ID = db.NewProfileID()
+	if err := db.SaveProfile(ctx, rec); err != nil {
+		return "", err
+	}
+	return rec.ID, nil
+}
+
 func profileRecordFromStats(stats Stats) db.ProfileRecord {
 	return db.ProfileRecord{
 		Name:            stats.Name,
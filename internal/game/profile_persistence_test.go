@@ -0,0 +1,119 @@
+package game
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestProfiles_KeepSeparateProgress(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "profiles.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	aoi, err := CreateProfile(ctx, "Aoi", "Grammar Mage")
+	if err != nil {
+		t.Fatalf("CreateProfile error: %v", err)
+	}
+	ren, err := CreateProfile(ctx, "Ren", "Conversation Bard")
+	if err != nil {
+		t.Fatalf("CreateProfile error: %v", err)
+	}
+	if _, err := CreateProfile(ctx, "  ", "Grammar Mage"); err == nil {
+		t.Fatal("expected an error for an empty name")
+	}
+	if _, err := CreateProfile(ctx, "Sora", "Dragon Tamer"); err == nil {
+		t.Fatal("expected an error for an unknown class")
+	}
+
+	stats, err := ActivateProfile(ctx, aoi)
+	if err != nil {
+		t.Fatalf("ActivateProfile error: %v", err)
+	}
+	if stats.Name != "Aoi" || stats.Class != "Grammar Mage" || db.CurrentProfileID() != aoi {
+		t.Fatalf("expected Aoi to be active, got %q (%s)", stats.Name, db.CurrentProfileID())
+	}
+	if _, _, err := RunVocabSession(ctx, stats, []VocabAnswer{{Correct: true}, {Correct: true}}); err != nil {
+		t.Fatalf("RunVocabSession error: %v", err)
+	}
+
+	stats, err = ActivateProfile(ctx, ren)
+	if err != nil {
+		t.Fatalf("ActivateProfile error: %v", err)
+	}
+	if stats.Exp != 0 {
+		t.Fatalf("Ren should start fresh, got %d EXP", stats.Exp)
+	}
+	if sessions, _ := db.ListSessions(ctx, ren, 10); len(sessions) != 0 {
+		t.Fatalf("Ren should have no history, got %d sessions", len(sessions))
+	}
+
+	if err := db.DeleteProfile(ctx, aoi); err != nil {
+		t.Fatalf("DeleteProfile error: %v", err)
+	}
+	if sessions, _ := db.ListSessions(ctx, aoi, 10); len(sessions) != 0 {
+		t.Fatalf("deleting Aoi should remove their history, got %d sessions", len(sessions))
+	}
+	profiles, err := db.ListProfiles(ctx)
+	if err != nil || len(profiles) != 1 || profiles[0].ID != ren {
+		t.Fatalf("expected only Ren to remain, got %+v (%v)", profiles, err)
+	}
+}
+
+func TestStartOver_DropsPreviousCharacter(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "startover.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	id, err := CreateProfile(ctx, "Aoi", "Grammar Mage")
+	if err != nil {
+		t.Fatalf("CreateProfile error: %v", err)
+	}
+	stats, err := ActivateProfile(ctx, id)
+	if err != nil {
+		t.Fatalf("ActivateProfile error: %v", err)
+	}
+	if _, _, err := RunVocabSession(ctx, stats, []VocabAnswer{{Correct: true}, {Correct: true}}); err != nil {
+		t.Fatalf("RunVocabSession error: %v", err)
+	}
+	if err := db.AddItems(ctx, id, "hp_potion", 3); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+	if _, err := db.RecordBossVictory(ctx, id, "stone_golem", time.Now()); err != nil {
+		t.Fatalf("RecordBossVictory error: %v", err)
+	}
+
+	fresh, err := NewCharacter("Ren", "Conversation Bard")
+	if err != nil {
+		t.Fatalf("NewCharacter error: %v", err)
+	}
+	if err := StartOver(ctx, fresh); err != nil {
+		t.Fatalf("StartOver error: %v", err)
+	}
+	rec, err := db.LoadProfile(ctx, id)
+	if err != nil || rec.Name != "Ren" || rec.Class != "Conversation Bard" || rec.Exp != 0 {
+		t.Fatalf("expected the fresh character under the same ID, got %+v (%v)", rec, err)
+	}
+	if n, _ := db.ItemCount(ctx, id, "hp_potion"); n != 0 {
+		t.Fatalf("expected items to be dropped, got %d potions", n)
+	}
+	if wins, _ := db.ListBossVictories(ctx, id); len(wins) != 0 {
+		t.Fatalf("expected boss victories to be dropped, got %v", wins)
+	}
+	if sessions, _ := db.ListSessions(ctx, id, 10); len(sessions) != 0 {
+		t.Fatalf("expected history to be dropped, got %d sessions", len(sessions))
+	}
+}
+
+func TestDefaultStats_HaveNoClassBonus(t *testing.T) {
+	if got := classExp(DefaultStats().Class, "vocab", 100); got != 100 {
+		t.Fatalf("expected no bonus for the default class, got %d EXP", got)
+	}
+}
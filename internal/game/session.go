@@ -3,19 +3,32 @@ package game
 import (
 	"context"
 	"log"
+	"math"
 	"time"
 
 	"tui-english-quest/internal/db"
 )
 
+// QuestionLog describes what was asked and answered, for the per-question log.
+type QuestionLog struct {
+	Prompt        string
+	Options       []string
+	Chosen        string
+	CorrectAnswer string
+	Explanation   string
+	Latency       time.Duration // time taken to answer; 0 when not measured
+}
+
 // VocabAnswer represents correctness per question.
 type VocabAnswer struct {
 	Correct bool
+	Item    QuestionLog
 }
 
 // GrammarAnswer represents correctness per floor.
 type GrammarAnswer struct {
 	Correct bool
+	Item    QuestionLog
 }
 
 // SessionSummary summarizes a game session.
@@ -27,9 +40,21 @@ type SessionSummary struct {
 	GoldDelta    int
 	BestCombo    int
 	Fainted      bool
+	Cleared      bool // finished without fainting; tavern: within AllowedMisses fails; boss: defeated
 	LeveledUp    bool
 	Note         string  // For errors or special messages
 	DefenseDelta float64 // Added for Grammar Dungeon
+	Total        int     // questions or turns in the session
+	Streak       StreakChange
+	PotionsUsed  int           // HP potions drunk to avoid fainting
+	Unlocked     []Achievement // achievements unlocked by this session
+	FastAnswers  int           // correct answers within FastAnswerTime
+	Review       bool          // a review run; see WithReview
+	// Boss battles only.
+	BossID        string
+	BossHP        int  // boss HP left when the battle ended
+	BossDefeated  bool // the boss fell before the player
+	BossVictories int  // wins against this boss so far, including this one
 }
 
 // ApplyFaint checks if the player has fainted and applies penalties.
@@ -49,7 +74,11 @@ func LeveledUp(before Stats, after Stats) bool {
 // RunVocabSession applies vocabulary battle rules for 5 questions.
 func RunVocabSession(ctx context.Context, stats Stats, answers []VocabAnswer) (Stats, SessionSummary, error) {
 	startedAt := time.Now()
-	summary := SessionSummary{Mode: "vocab"}
+	summary := SessionSummary{Mode: "vocab", Total: len(answers), Review: isReview(ctx)}
+	items := make([]db.SessionItem, 0, len(answers))
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Correct, ""))
+	}
 	before := stats
 	combo := stats.Combo
 	bestCombo := combo
@@ -60,18 +89,20 @@ func RunVocabSession(ctx context.Context, stats Stats, answers []VocabAnswer) (S
 	// Compute allowed misses and damage per miss
 	N := len(answers)
 	M := AllowedMisses(N)
-	dmg := DamagePerMiss(stats.MaxHP, M)
+	gear := EquipmentEffects(ctx, summary.Mode)
+	dmg := gear.Damage(DamagePerMiss(stats.MaxHP, M))
 
 	sumCorrectExp := 0
 	hpDelta := 0
 	fainted := false
 	for i, a := range answers {
 		if a.Correct {
-			// increment combo
-			tmp := Stats{Combo: combo}
-			combo = AddCombo(tmp).Combo
+			combo = comboAfterCorrect(combo, a.Item.Latency)
+			if IsFast(a.Item.Latency) {
+				summary.FastAnswers++
+			}
 			_, tierMul := TierForLevel(stats.Level)
-			qexp := QExpFor(baseExp, tierMul, false)
+			qexp := speedExp(QExpFor(baseExp, tierMul, false), a.Item.Latency)
 			sumCorrectExp += qexp
 			if combo > bestCombo {
 				bestCombo = combo
@@ -84,6 +115,12 @@ func RunVocabSession(ctx context.Context, stats Stats, answers []VocabAnswer) (S
 			stats.HP -= dmg
 			if stats.HP <= 0 {
 				stats.HP = 0
+				if healed, heal := drinkPotion(ctx, stats); heal > 0 {
+					stats = healed
+					hpDelta += heal
+					summary.PotionsUsed++
+					continue
+				}
 				fainted = true
 				// stop processing further questions
 				// truncate answers considered to i+1
@@ -98,15 +135,19 @@ func RunVocabSession(ctx context.Context, stats Stats, answers []VocabAnswer) (S
 	var sessionExp int
 	if !fainted && len(answers) == N {
 		// clear
+		summary.Cleared = N > 0 && !summary.Review
 		_, tierMul := TierForLevel(stats.Level)
 		clearBonus := ClearBonus(N, baseExp, tierMul)
+		if summary.Review {
+			clearBonus = 0
+		}
 		allCorrect := countVocabCorrect(answers) == N
-		sessionExp = SessionExpClear(sumCorrectExp, clearBonus, allCorrect, N, true)
-		stats = GainExp(stats, sessionExp)
+		sessionExp = SessionExpClear(sumCorrectExp, clearBonus, allCorrect, N, !summary.Review)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 	} else {
 		// fail
 		sessionExp = SessionExpFail(sumCorrectExp, 0.40)
-		stats = GainExp(stats, sessionExp)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 		if fainted {
 			stats = ApplyFaintPenalty(stats)
 		}
@@ -120,17 +161,17 @@ func RunVocabSession(ctx context.Context, stats Stats, answers []VocabAnswer) (S
 	summary.LeveledUp = LeveledUp(before, stats)
 
 	endedAt := time.Now()
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
 	rec := db.NewSessionRecord("vocab", startedAt, endedAt)
+	rec.Review = summary.Review
 	rec.CorrectCount = summary.Correct
 	rec.BestCombo = summary.BestCombo
 	rec.ExpGained = summary.ExpDelta
 	rec.HPDelta = summary.HPDelta
 	rec.Fainted = summary.Fainted
 	rec.LeveledUp = summary.LeveledUp
-	_ = db.SaveSession(ctx, rec)
-	if err := SaveStats(ctx, stats); err != nil {
-		log.Printf("failed to persist profile: %v", err)
-	}
+	recordSession(ctx, rec, items, stats)
 	return stats, summary, nil
 }
 
@@ -147,32 +188,51 @@ func countVocabCorrect(ans []VocabAnswer) int {
 // RunGrammarSession applies grammar dungeon rules for 5 floors.
 func RunGrammarSession(ctx context.Context, stats Stats, answers []GrammarAnswer) (Stats, SessionSummary, error) {
 	startedAt := time.Now()
-	summary := SessionSummary{Mode: "grammar"}
+	summary := SessionSummary{Mode: "grammar", Total: len(answers), Review: isReview(ctx)}
+	items := make([]db.SessionItem, 0, len(answers))
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Correct, ""))
+	}
 	before := stats
 	baseExp := 3
 	// Ensure MaxHP is in sync with level
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	N := len(answers)
 	M := AllowedMisses(N)
-	dmg := DamagePerMiss(stats.MaxHP, M)
+	gear := EquipmentEffects(ctx, summary.Mode)
+	dmg := gear.Damage(DamagePerMiss(stats.MaxHP, M))
 
 	sumCorrectExp := 0
 	hpDelta := 0
 	defDelta := 0.0
 	correct := 0
+	combo := stats.Combo
+	bestCombo := combo
 	fainted := false
 	for i, a := range answers {
 		if a.Correct {
 			_, tierMul := TierForLevel(stats.Level)
-			qexp := QExpFor(baseExp, tierMul, false)
+			qexp := speedExp(QExpFor(baseExp, tierMul, false), a.Item.Latency)
 			sumCorrectExp += qexp
 			defDelta += 0.2
+			combo = comboAfterCorrect(combo, a.Item.Latency)
+			bestCombo = max(bestCombo, combo)
+			if IsFast(a.Item.Latency) {
+				summary.FastAnswers++
+			}
 			correct++
 		} else {
+			combo = ResetCombo(Stats{Combo: combo}).Combo
 			hpDelta -= dmg
 			stats.HP -= dmg
 			if stats.HP <= 0 {
 				stats.HP = 0
+				if healed, heal := drinkPotion(ctx, stats); heal > 0 {
+					stats = healed
+					hpDelta += heal
+					summary.PotionsUsed++
+					continue
+				}
 				fainted = true
 				answers = answers[:i+1]
 				break
@@ -180,20 +240,25 @@ func RunGrammarSession(ctx context.Context, stats Stats, answers []GrammarAnswer
 		}
 	}
 
+	stats.Combo = combo
 	stats = AddDefense(stats, defDelta)
 
 	var sessionExp int
 	if !fainted && len(answers) == N {
 		// clear
+		summary.Cleared = N > 0 && !summary.Review
 		_, tierMul := TierForLevel(stats.Level)
 		clearBonus := ClearBonus(N, baseExp, tierMul)
+		if summary.Review {
+			clearBonus = 0
+		}
 		allCorrect := correct == N
-		sessionExp = SessionExpClear(sumCorrectExp, clearBonus, allCorrect, N, true)
-		stats = GainExp(stats, sessionExp)
+		sessionExp = SessionExpClear(sumCorrectExp, clearBonus, allCorrect, N, !summary.Review)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 	} else {
 		// fail
 		sessionExp = SessionExpFail(sumCorrectExp, 0.40)
-		stats = GainExp(stats, sessionExp)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 		if fainted {
 			stats = ApplyFaintPenalty(stats)
 		}
@@ -203,21 +268,23 @@ func RunGrammarSession(ctx context.Context, stats Stats, answers []GrammarAnswer
 	summary.ExpDelta = sessionExp
 	summary.HPDelta = hpDelta
 	summary.DefenseDelta = defDelta
+	summary.BestCombo = bestCombo
 	summary.Fainted = fainted
 	summary.LeveledUp = LeveledUp(before, stats)
 
 	endedAt := time.Now()
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
 	rec := db.NewSessionRecord("grammar", startedAt, endedAt)
+	rec.Review = summary.Review
 	rec.CorrectCount = summary.Correct
+	rec.BestCombo = summary.BestCombo
 	rec.ExpGained = summary.ExpDelta
 	rec.HPDelta = summary.HPDelta
 	rec.DefenseDelta = summary.DefenseDelta
 	rec.Fainted = summary.Fainted
 	rec.LeveledUp = summary.LeveledUp
-	_ = db.SaveSession(ctx, rec)
-	if err := SaveStats(ctx, stats); err != nil {
-		log.Printf("failed to persist profile: %v", err)
-	}
+	recordSession(ctx, rec, items, stats)
 	return stats, summary, nil
 }
 
@@ -231,7 +298,7 @@ func countGrammarCorrect(ans []GrammarAnswer) int {
 	return c
 }
 
-// Dummy functions for other modes to avoid compilation errors for now
+// TavernOutcome is the rubric grade of one tavern turn.
 type TavernOutcome int
 
 const (
@@ -240,8 +307,101 @@ const (
 	OutcomeFail
 )
 
-func RunTavernSession(ctx context.Context, stats Stats, outcomes []TavernOutcome) (Stats, SessionSummary, error) {
-	return stats, SessionSummary{}, nil
+// String returns the outcome name used by the evaluator and the per-question log.
+func (o TavernOutcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeNormal:
+		return "normal"
+	default:
+		return "fail"
+	}
+}
+
+// ParseTavernOutcome maps an evaluator outcome string; unknown values count as normal.
+func ParseTavernOutcome(s string) TavernOutcome {
+	switch s {
+	case "success":
+		return OutcomeSuccess
+	case "fail":
+		return OutcomeFail
+	default:
+		return OutcomeNormal
+	}
+}
+
+// TavernAnswer represents the evaluated outcome per turn.
+type TavernAnswer struct {
+	Outcome TavernOutcome
+	Item    QuestionLog
+}
+
+// RunTavernSession applies conversation tavern rules. Turns never cost HP;
+// success/normal/fail earn base EXP 5/3/1 and Gold 10/5/0, scaled by tier.
+func RunTavernSession(ctx context.Context, stats Stats, answers []TavernAnswer) (Stats, SessionSummary, error) {
+	startedAt := time.Now()
+	summary := SessionSummary{Mode: "tavern", Total: len(answers)}
+	before := stats
+	gear := EquipmentEffects(ctx, summary.Mode)
+	baseExp := 3
+	_, tierMul := TierForLevel(stats.Level)
+	N := len(answers)
+
+	items := make([]db.SessionItem, 0, N)
+	sumTurnExp := 0
+	fails := 0
+	gold := 0
+	combo := stats.Combo
+	bestCombo := combo
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Outcome == OutcomeSuccess, a.Outcome.String()))
+		switch a.Outcome {
+		case OutcomeSuccess:
+			sumTurnExp += QExpFor(5, tierMul, false)
+			gold += 10
+			summary.Correct++
+			combo = AddCombo(Stats{Combo: combo}).Combo
+			if combo > bestCombo {
+				bestCombo = combo
+			}
+		case OutcomeNormal:
+			sumTurnExp += QExpFor(3, tierMul, false)
+			gold += 5
+		default:
+			sumTurnExp += QExpFor(1, tierMul, false)
+			fails++
+			combo = ResetCombo(Stats{Combo: combo}).Combo
+		}
+	}
+	stats.Combo = combo
+
+	sessionExp := 0
+	if N > 0 {
+		clearBonus := ClearBonus(N, baseExp, tierMul)
+		sessionExp = SessionExpClear(sumTurnExp, clearBonus, summary.Correct == N, N, true)
+	}
+	goldDelta := int(math.Round(float64(gold) * tierMul))
+	stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
+	stats = AddGold(stats, goldDelta)
+
+	summary.ExpDelta = sessionExp
+	summary.GoldDelta = goldDelta
+	summary.BestCombo = bestCombo
+	summary.Cleared = N > 0 && fails <= AllowedMisses(N)
+	summary.LeveledUp = LeveledUp(before, stats)
+
+	endedAt := time.Now()
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
+	rec := db.NewSessionRecord("tavern", startedAt, endedAt)
+	rec.CorrectCount = summary.Correct
+	rec.BestCombo = summary.BestCombo
+	rec.ExpGained = summary.ExpDelta
+	rec.GoldDelta = summary.GoldDelta
+	rec.LeveledUp = summary.LeveledUp
+	recordSession(ctx, rec, items, stats)
+	return stats, summary, nil
 }
 
 type SpellingOutcome int
@@ -252,52 +412,127 @@ const (
 	SpellingFail
 )
 
-func RunSpellingSession(ctx context.Context, stats Stats, outcomes []SpellingOutcome) (Stats, SessionSummary, error) {
+// String returns the outcome name stored in the per-question log.
+func (o SpellingOutcome) String() string {
+	switch o {
+	case SpellingPerfect:
+		return "perfect"
+	case SpellingNear:
+		return "near"
+	default:
+		return "fail"
+	}
+}
+
+// SpellingAnswer represents the graded outcome per prompt.
+type SpellingAnswer struct {
+	Outcome SpellingOutcome
+	Item    QuestionLog
+}
+
+func RunSpellingSession(ctx context.Context, stats Stats, answers []SpellingAnswer) (Stats, SessionSummary, error) {
 	startedAt := time.Now()
-	summary := SessionSummary{Mode: "spelling"}
+	summary := SessionSummary{Mode: "spelling", Total: len(answers), Review: isReview(ctx)}
 	before := stats
+	gear := EquipmentEffects(ctx, summary.Mode)
 	expDelta := 0
 	hpDelta := 0
-	for _, o := range outcomes {
-		switch o {
+	combo := stats.Combo
+	bestCombo := combo
+	items := make([]db.SessionItem, 0, len(answers))
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Outcome == SpellingPerfect, a.Outcome.String()))
+		switch a.Outcome {
 		case SpellingPerfect:
-			expDelta += 5
+			expDelta += speedExp(5, a.Item.Latency)
 			summary.Correct++
+			combo = comboAfterCorrect(combo, a.Item.Latency)
+			bestCombo = max(bestCombo, combo)
+			if IsFast(a.Item.Latency) {
+				summary.FastAnswers++
+			}
 		case SpellingNear:
 			expDelta += 2
 			var delta int
-			stats, delta = applyDamageDelta(stats, 5)
+			stats, delta = applyDamageDelta(stats, gear.Damage(5))
 			hpDelta += delta
 		case SpellingFail:
 			expDelta += 1
+			combo = ResetCombo(Stats{Combo: combo}).Combo
 			var delta int
-			stats, delta = applyDamageDelta(stats, 12)
+			stats, delta = applyDamageDelta(stats, gear.Damage(12))
 			hpDelta += delta
 		default:
 			expDelta += 1
 		}
+		if healed, heal := drinkPotion(ctx, stats); heal > 0 {
+			stats = healed
+			hpDelta += heal
+			summary.PotionsUsed++
+		}
 	}
 
-	stats = GainExp(stats, expDelta)
+	stats.Combo = combo
+	stats, expDelta = gainSessionExp(stats, summary.Mode, gear, expDelta)
 	stats, fainted := applyFaintIfNeeded(stats)
 
 	summary.ExpDelta = expDelta
 	summary.HPDelta = hpDelta
+	summary.BestCombo = bestCombo
 	summary.Fainted = fainted
+	summary.Cleared = !fainted && len(answers) > 0 && !summary.Review
 	summary.LeveledUp = LeveledUp(before, stats)
 
 	endedAt := time.Now()
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
 	rec := db.NewSessionRecord("spelling", startedAt, endedAt)
+	rec.Review = summary.Review
 	rec.CorrectCount = summary.Correct
+	rec.BestCombo = summary.BestCombo
 	rec.ExpGained = summary.ExpDelta
 	rec.HPDelta = summary.HPDelta
 	rec.Fainted = summary.Fainted
 	rec.LeveledUp = summary.LeveledUp
-	_ = db.SaveSession(ctx, rec)
+	recordSession(ctx, rec, items, stats)
+	return stats, summary, nil
+}
+
+// newSessionItem converts a QuestionLog into a row for db.SaveSessionItems.
+func newSessionItem(seq int, q QuestionLog, correct bool, outcome string) db.SessionItem {
+	return db.SessionItem{
+		Seq:           seq,
+		Prompt:        q.Prompt,
+		Options:       q.Options,
+		Chosen:        q.Chosen,
+		CorrectAnswer: q.CorrectAnswer,
+		Explanation:   q.Explanation,
+		Correct:       correct,
+		Outcome:       outcome,
+		Latency:       q.Latency,
+	}
+}
+
+// recordSession persists the session row, its per-question log, review queue
+// updates and the updated profile. Persistence failures are logged, not
+// returned, so a broken database never blocks the result screen.
+func recordSession(ctx context.Context, rec db.SessionRecord, items []db.SessionItem, stats Stats) {
+	if err := db.SaveSession(ctx, rec); err != nil {
+		log.Printf("failed to save session: %v", err)
+	} else {
+		for i := range items {
+			items[i].SessionID = rec.ID
+			items[i].PlayerID = rec.PlayerID
+			items[i].Mode = rec.Mode
+		}
+		if err := db.SaveSessionItems(ctx, items); err != nil {
+			log.Printf("failed to save session items: %v", err)
+		}
+		scheduleReviews(ctx, items, rec.EndedAt)
+	}
 	if err := SaveStats(ctx, stats); err != nil {
 		log.Printf("failed to persist profile: %v", err)
 	}
-	return stats, summary, nil
 }
 
 func applyDamageDelta(s Stats, dmg int) (Stats, int) {
@@ -314,34 +549,57 @@ func applyFaintIfNeeded(s Stats) (Stats, bool) {
 	return s, false
 }
 
-type ListeningAnswer struct{ Correct bool }
+// ListeningAnswer represents correctness per audio item.
+type ListeningAnswer struct {
+	Correct bool
+	Item    QuestionLog
+}
 
 func RunListeningSession(ctx context.Context, stats Stats, answers []ListeningAnswer) (Stats, SessionSummary, error) {
 	startedAt := time.Now()
-	summary := SessionSummary{Mode: "listening"}
+	summary := SessionSummary{Mode: "listening", Total: len(answers)}
+	items := make([]db.SessionItem, 0, len(answers))
+	for i, a := range answers {
+		items = append(items, newSessionItem(i, a.Item, a.Correct, ""))
+	}
 	before := stats
 	baseExp := 5
 	// Ensure MaxHP is in sync with level
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	N := len(answers)
 	M := AllowedMisses(N)
-	dmg := DamagePerMiss(stats.MaxHP, M)
+	gear := EquipmentEffects(ctx, summary.Mode)
+	dmg := gear.Damage(DamagePerMiss(stats.MaxHP, M))
 
 	sumCorrectExp := 0
 	hpDelta := 0
 	correct := 0
+	combo := stats.Combo
+	bestCombo := combo
 	fainted := false
 	for i, a := range answers {
 		if a.Correct {
 			_, tierMul := TierForLevel(stats.Level)
-			qexp := QExpFor(baseExp, tierMul, false)
+			qexp := speedExp(QExpFor(baseExp, tierMul, false), a.Item.Latency)
 			sumCorrectExp += qexp
 			correct++
+			combo = comboAfterCorrect(combo, a.Item.Latency)
+			bestCombo = max(bestCombo, combo)
+			if IsFast(a.Item.Latency) {
+				summary.FastAnswers++
+			}
 		} else {
+			combo = ResetCombo(Stats{Combo: combo}).Combo
 			hpDelta -= dmg
 			stats.HP -= dmg
 			if stats.HP <= 0 {
 				stats.HP = 0
+				if healed, heal := drinkPotion(ctx, stats); heal > 0 {
+					stats = healed
+					hpDelta += heal
+					summary.PotionsUsed++
+					continue
+				}
 				fainted = true
 				answers = answers[:i+1]
 				break
@@ -349,16 +607,19 @@ func RunListeningSession(ctx context.Context, stats Stats, answers []ListeningAn
 		}
 	}
 
+	stats.Combo = combo
+
 	var sessionExp int
 	if !fainted && len(answers) == N {
+		summary.Cleared = N > 0
 		_, tierMul := TierForLevel(stats.Level)
 		clearBonus := ClearBonus(N, baseExp, tierMul)
 		allCorrect := correct == N
 		sessionExp = SessionExpClear(sumCorrectExp, clearBonus, allCorrect, N, true)
-		stats = GainExp(stats, sessionExp)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 	} else {
 		sessionExp = SessionExpFail(sumCorrectExp, 0.40)
-		stats = GainExp(stats, sessionExp)
+		stats, sessionExp = gainSessionExp(stats, summary.Mode, gear, sessionExp)
 		if fainted {
 			stats = ApplyFaintPenalty(stats)
 		}
@@ -367,19 +628,20 @@ func RunListeningSession(ctx context.Context, stats Stats, answers []ListeningAn
 	summary.Correct = correct
 	summary.ExpDelta = sessionExp
 	summary.HPDelta = hpDelta
+	summary.BestCombo = bestCombo
 	summary.Fainted = fainted
 	summary.LeveledUp = LeveledUp(before, stats)
 
 	endedAt := time.Now()
+	stats, summary.Streak = updateStreak(ctx, stats, endedAt)
+	summary.Unlocked = unlockAchievements(ctx, stats, summary, endedAt)
 	rec := db.NewSessionRecord("listening", startedAt, endedAt)
 	rec.CorrectCount = summary.Correct
+	rec.BestCombo = summary.BestCombo
 	rec.ExpGained = summary.ExpDelta
 	rec.HPDelta = summary.HPDelta
 	rec.Fainted = summary.Fainted
 	rec.LeveledUp = summary.LeveledUp
-	_ = db.SaveSession(ctx, rec)
-	if err := SaveStats(ctx, stats); err != nil {
-		log.Printf("failed to persist profile: %v", err)
-	}
+	recordSession(ctx, rec, items, stats)
 	return stats, summary, nil
 }
@@ -2,7 +2,11 @@ package game
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
 )
 
 func TestRunVocabSession_AllCorrect(t *testing.T) {
@@ -10,7 +14,7 @@ func TestRunVocabSession_AllCorrect(t *testing.T) {
 	stats.Level = 10
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	stats.HP = stats.MaxHP
-	answers := []VocabAnswer{{true}, {true}, {true}, {true}, {true}}
+	answers := []VocabAnswer{{Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
 	updated, summary, err := RunVocabSession(context.Background(), stats, answers)
 	if err != nil {
 		t.Fatalf("RunVocabSession error: %v", err)
@@ -32,7 +36,7 @@ func TestRunVocabSession_IncorrectReducesHP(t *testing.T) {
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	stats.HP = stats.MaxHP
 	// one incorrect at first
-	answers := []VocabAnswer{{false}, {true}, {true}, {true}, {true}}
+	answers := []VocabAnswer{{Correct: false}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
 	updated, summary, err := RunVocabSession(context.Background(), stats, answers)
 	if err != nil {
 		t.Fatalf("RunVocabSession error: %v", err)
@@ -100,8 +104,8 @@ func TestRunSpellingSession_PerfectIncreasesExp(t *testing.T) {
 	stats := DefaultStats()
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	stats.HP = stats.MaxHP
-	outcomes := []SpellingOutcome{SpellingPerfect, SpellingPerfect}
-	updated, summary, err := RunSpellingSession(context.Background(), stats, outcomes)
+	answers := []SpellingAnswer{{Outcome: SpellingPerfect}, {Outcome: SpellingPerfect}}
+	updated, summary, err := RunSpellingSession(context.Background(), stats, answers)
 	if err != nil {
 		t.Fatalf("RunSpellingSession error: %v", err)
 	}
@@ -123,8 +127,8 @@ func TestRunSpellingSession_FaintAppliesPenalty(t *testing.T) {
 	stats := DefaultStats()
 	stats.MaxHP = MaxHPForLevel(stats.Level)
 	stats.HP = 10 // force low HP to trigger faint
-	outcomes := []SpellingOutcome{SpellingFail}
-	updated, summary, err := RunSpellingSession(context.Background(), stats, outcomes)
+	answers := []SpellingAnswer{{Outcome: SpellingFail}}
+	updated, summary, err := RunSpellingSession(context.Background(), stats, answers)
 	if err != nil {
 		t.Fatalf("RunSpellingSession error: %v", err)
 	}
@@ -141,3 +145,135 @@ func TestRunSpellingSession_FaintAppliesPenalty(t *testing.T) {
 		t.Fatalf("expected ExpDelta 1 for fail, got %d", summary.ExpDelta)
 	}
 }
+
+func TestRunVocabSession_LogsItems(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "items.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+
+	stats := DefaultStats()
+	answers := []VocabAnswer{
+		{Correct: true, Item: QuestionLog{Prompt: "apple", Options: []string{"りんご", "みかん", "ぶどう", "もも"}, Chosen: "りんご", CorrectAnswer: "りんご", Latency: 3200 * time.Millisecond}},
+		{Correct: false, Item: QuestionLog{Prompt: "grape", Options: []string{"りんご", "みかん", "ぶどう", "もも"}, Chosen: "もも", CorrectAnswer: "ぶどう", Explanation: "grape = ぶどう"}},
+	}
+	if _, _, err := RunVocabSession(context.Background(), stats, answers); err != nil {
+		t.Fatalf("RunVocabSession error: %v", err)
+	}
+
+	sessions, err := db.ListSessions(context.Background(), "player-1", 1)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("expected one saved session, got %d (%v)", len(sessions), err)
+	}
+	items, err := db.ListSessionItems(context.Background(), sessions[0].ID)
+	if err != nil {
+		t.Fatalf("ListSessionItems error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 logged items, got %d", len(items))
+	}
+	if items[1].Prompt != "grape" || items[1].Chosen != "もも" || items[1].Correct || len(items[1].Options) != 4 || items[1].Mode != "vocab" {
+		t.Fatalf("unexpected logged item: %+v", items[1])
+	}
+	if items[0].Latency != 3200*time.Millisecond || items[1].Latency != 0 {
+		t.Fatalf("expected latencies 3.2s and 0, got %v and %v", items[0].Latency, items[1].Latency)
+	}
+}
+
+func TestRunTavernSession_RewardsWithoutHPLoss(t *testing.T) {
+	stats := DefaultStats()
+	stats.MaxHP = MaxHPForLevel(stats.Level)
+	stats.HP = stats.MaxHP
+	answers := []TavernAnswer{
+		{Outcome: OutcomeSuccess}, {Outcome: OutcomeNormal}, {Outcome: OutcomeFail},
+		{Outcome: OutcomeSuccess}, {Outcome: OutcomeNormal},
+	}
+	updated, summary, err := RunTavernSession(context.Background(), stats, answers)
+	if err != nil {
+		t.Fatalf("RunTavernSession error: %v", err)
+	}
+	// Tier 1: turns 5+3+1+5+3 = 17, clear bonus round(5*3*0.5) = 8.
+	if summary.ExpDelta != 25 {
+		t.Fatalf("expected ExpDelta 25, got %d", summary.ExpDelta)
+	}
+	if summary.GoldDelta != 30 || updated.Gold != stats.Gold+30 {
+		t.Fatalf("expected 30 gold persisted to stats, got delta %d gold %d", summary.GoldDelta, updated.Gold)
+	}
+	if summary.Correct != 2 {
+		t.Fatalf("expected 2 successes, got %d", summary.Correct)
+	}
+	if updated.HP != stats.HP || summary.HPDelta != 0 || summary.Fainted {
+		t.Fatalf("tavern must not cost HP, got HP %d delta %d", updated.HP, summary.HPDelta)
+	}
+}
+
+func TestRunTavernSession_TierScaling(t *testing.T) {
+	low := DefaultStats()
+	high := DefaultStats()
+	high.Level = 50
+	answers := []TavernAnswer{{Outcome: OutcomeSuccess}, {Outcome: OutcomeSuccess}}
+
+	_, lowSum, _ := RunTavernSession(context.Background(), low, answers)
+	_, highSum, _ := RunTavernSession(context.Background(), high, answers)
+	if highSum.ExpDelta <= lowSum.ExpDelta || highSum.GoldDelta <= lowSum.GoldDelta {
+		t.Fatalf("expected higher tier to scale rewards, low %+v high %+v", lowSum, highSum)
+	}
+	if highSum.GoldDelta != 30 {
+		t.Fatalf("expected tier 3 gold 20*1.5 = 30, got %d", highSum.GoldDelta)
+	}
+}
+
+func TestRunTavernSession_PersistsStats(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "tavern.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	stats := DefaultStats()
+	updated, _, err := RunTavernSession(ctx, stats, []TavernAnswer{{Outcome: OutcomeSuccess, Item: QuestionLog{Prompt: "Welcome!", Chosen: "Hello, one ale please."}}})
+	if err != nil {
+		t.Fatalf("RunTavernSession error: %v", err)
+	}
+	rec, err := db.LoadProfile(ctx, "player-1")
+	if err != nil {
+		t.Fatalf("LoadProfile error: %v", err)
+	}
+	if rec.Gold != updated.Gold || rec.Exp != updated.Exp {
+		t.Fatalf("expected persisted gold/exp %d/%d, got %d/%d", updated.Gold, updated.Exp, rec.Gold, rec.Exp)
+	}
+	sessions, err := db.ListSessions(ctx, "player-1", 1)
+	if err != nil || len(sessions) != 1 || sessions[0].Mode != "tavern" || sessions[0].GoldDelta != updated.Gold-stats.Gold {
+		t.Fatalf("expected saved tavern session, got %+v (%v)", sessions, err)
+	}
+}
+
+func TestClassBonus_AppliesOnlyInSpecialtyMode(t *testing.T) {
+	warrior, err := NewCharacter("Aoi", "Vocabulary Warrior")
+	if err != nil {
+		t.Fatalf("NewCharacter error: %v", err)
+	}
+	mage, err := NewCharacter("Ren", "Grammar Mage")
+	if err != nil {
+		t.Fatalf("NewCharacter error: %v", err)
+	}
+	answers := []GrammarAnswer{{Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
+
+	_, plain, _ := RunGrammarSession(context.Background(), warrior, answers)
+	updated, boosted, _ := RunGrammarSession(context.Background(), mage, answers)
+	// Tier 1 clear: (5*3 + 8) * 1.25 perfect = 29; the mage earns +20% -> 35.
+	if plain.ExpDelta != 29 || boosted.ExpDelta != 35 {
+		t.Fatalf("expected 29 and 35 EXP, got %d and %d", plain.ExpDelta, boosted.ExpDelta)
+	}
+	if updated.Level != 2 || updated.Exp != 35-30 {
+		t.Fatalf("expected the boosted EXP to be gained, got LV%d %d EXP", updated.Level, updated.Exp)
+	}
+
+	_, vocab, _ := RunVocabSession(context.Background(), mage, []VocabAnswer{{Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}})
+	_, warriorVocab, _ := RunVocabSession(context.Background(), warrior, []VocabAnswer{{Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}})
+	if warriorVocab.ExpDelta <= vocab.ExpDelta {
+		t.Fatalf("expected the warrior bonus in vocab only, got %d vs %d", warriorVocab.ExpDelta, vocab.ExpDelta)
+	}
+}
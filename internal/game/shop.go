@@ -0,0 +1,190 @@
+package game
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+
+	"tui-english-quest/internal/db"
+)
+
+// ItemHPPotion is the player_items ID of an HP potion. Potions are drunk
+// automatically when HP drops to zero during a session.
+const ItemHPPotion = "hp_potion"
+
+// Kinds of shop offers.
+const (
+	OfferEquipment = "equipment"
+	OfferItem      = "item"
+)
+
+//go:embed shop.json
+var shopJSON []byte
+
+// ShopItem is a consumable listed in shop.json.
+type ShopItem struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Price       int     `json:"price"`
+	Limit       int     `json:"limit"`          // most a player may hold; 0 means no limit
+	Heal        float64 `json:"heal,omitempty"` // fraction of MaxHP restored
+}
+
+// shopItems is the consumable catalog parsed from shop.json.
+var shopItems = mustParseShopItems(shopJSON)
+
+func mustParseShopItems(data []byte) []ShopItem {
+	var catalog struct {
+		Items []ShopItem `json:"items"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		panic(fmt.Sprintf("invalid shop catalog: %v", err))
+	}
+	return catalog.Items
+}
+
+// ShopItemByID returns the consumable called id.
+func ShopItemByID(id string) (ShopItem, bool) {
+	for _, it := range shopItems {
+		if it.ID == id {
+			return it, true
+		}
+	}
+	return ShopItem{}, false
+}
+
+// Offer is one line of the shop: a piece of equipment or a consumable.
+type Offer struct {
+	Kind      string // OfferEquipment or OfferItem
+	ID        string
+	Name      string
+	Price     int
+	Equipment db.EquipmentRecord // set for OfferEquipment
+	Item      ShopItem           // set for OfferItem
+	Owned     int                // 1 for owned equipment, quantity held for items
+}
+
+// SoldOut reports whether the player cannot hold any more of the offer.
+func (o Offer) SoldOut() bool {
+	if o.Kind == OfferEquipment {
+		return o.Owned > 0
+	}
+	return o.Item.Limit > 0 && o.Owned >= o.Item.Limit
+}
+
+// ShopOffers lists everything the shop sells to the active profile:
+// consumables first, then equipment that is not free starter gear.
+func ShopOffers(ctx context.Context) ([]Offer, error) {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return nil, ErrNoProfile
+	}
+	var offers []Offer
+	for _, it := range shopItems {
+		n, err := db.ItemCount(ctx, playerID, it.ID)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, Offer{Kind: OfferItem, ID: it.ID, Name: it.Name, Price: it.Price, Item: it, Owned: n})
+	}
+
+	catalog, err := db.ListEquipment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := db.ListOwnedEquipment(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	have := map[string]bool{}
+	for _, it := range owned {
+		have[it.ID] = true
+	}
+	for _, it := range catalog {
+		if it.Price <= 0 {
+			continue
+		}
+		o := Offer{Kind: OfferEquipment, ID: it.ID, Name: it.Name, Price: it.Price, Equipment: it}
+		if have[it.ID] {
+			o.Owned = 1
+		}
+		offers = append(offers, o)
+	}
+	return offers, nil
+}
+
+// Buy purchases offer for the active profile and returns stats with the gold
+// left. Gold and inventory are updated together in the database.
+func Buy(ctx context.Context, stats Stats, offer Offer) (Stats, error) {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return stats, ErrNoProfile
+	}
+	var gold int
+	var err error
+	switch offer.Kind {
+	case OfferEquipment:
+		gold, err = db.BuyEquipment(ctx, playerID, offer.ID)
+	case OfferItem:
+		it, ok := ShopItemByID(offer.ID)
+		if !ok {
+			return stats, fmt.Errorf("unknown shop item %q", offer.ID)
+		}
+		gold, err = db.BuyItem(ctx, playerID, it.ID, it.Price, it.Limit)
+	default:
+		return stats, fmt.Errorf("unknown offer kind %q", offer.Kind)
+	}
+	if err != nil {
+		return stats, err
+	}
+	stats.Gold = gold
+	return stats, nil
+}
+
+// drinkPotion revives a player whose HP has dropped to zero by consuming one
+// HP potion, returning the healed stats and the HP restored. It returns 0
+// when the player has no potion. Failures are logged and count as no potion.
+func drinkPotion(ctx context.Context, stats Stats) (Stats, int) {
+	playerID := db.CurrentProfileID()
+	if playerID == "" || stats.HP > 0 {
+		return stats, 0
+	}
+	heal := PotionHeal(stats)
+	if heal == 0 {
+		return stats, 0
+	}
+	n, err := db.ItemCount(ctx, playerID, ItemHPPotion)
+	if err != nil {
+		log.Printf("failed to load potions: %v", err)
+		return stats, 0
+	}
+	if n <= 0 {
+		return stats, 0
+	}
+	if err := db.AddItems(ctx, playerID, ItemHPPotion, -1); err != nil {
+		log.Printf("failed to use potion: %v", err)
+		return stats, 0
+	}
+	stats.HP = heal
+	return stats, stats.HP
+}
+
+// PotionHeal returns the HP one HP potion restores to a player with stats,
+// or 0 when the catalog has no potion.
+func PotionHeal(stats Stats) int {
+	potion, ok := ShopItemByID(ItemHPPotion)
+	if !ok {
+		return 0
+	}
+	heal := max(int(math.Round(float64(stats.MaxHP)*potion.Heal)), 1)
+	return min(heal, stats.MaxHP)
+}
+
+// HPPotions returns how many HP potions the active profile owns.
+func HPPotions(ctx context.Context) (int, error) {
+	return db.ItemCount(ctx, db.CurrentProfileID(), ItemHPPotion)
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestShop_BuySpendsGoldAndStocksInventory(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "shop.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	stats := DefaultStats()
+	stats.Gold = 300
+	if err := SaveStats(ctx, stats); err != nil {
+		t.Fatalf("SaveStats error: %v", err)
+	}
+	offers, err := ShopOffers(ctx)
+	if err != nil {
+		t.Fatalf("ShopOffers error: %v", err)
+	}
+	find := func(id string) Offer {
+		for _, o := range offers {
+			if o.ID == id {
+				return o
+			}
+		}
+		t.Fatalf("offer %s not in shop", id)
+		return Offer{}
+	}
+	for _, o := range offers {
+		if o.Kind == OfferEquipment && o.Price == 0 {
+			t.Fatalf("starter gear %s should not be sold", o.ID)
+		}
+	}
+
+	stats, err = Buy(ctx, stats, find("chain_mail"))
+	if err != nil || stats.Gold != 100 {
+		t.Fatalf("expected chain mail to cost 200, got gold %d (%v)", stats.Gold, err)
+	}
+	if err := Equip(ctx, "chain_mail"); err != nil {
+		t.Fatalf("expected bought gear to be equippable: %v", err)
+	}
+	if _, err := Buy(ctx, stats, find("chain_mail")); !errors.Is(err, db.ErrAlreadyOwned) {
+		t.Fatalf("expected a second chain mail to be refused, got %v", err)
+	}
+	if _, err := Buy(ctx, stats, find("iron_sword")); !errors.Is(err, db.ErrNotEnoughGold) {
+		t.Fatalf("expected not enough gold, got %v", err)
+	}
+
+	stats, err = Buy(ctx, stats, find(ItemHPPotion))
+	if err != nil {
+		t.Fatalf("Buy potion error: %v", err)
+	}
+	if n, _ := HPPotions(ctx); n != 1 {
+		t.Fatalf("expected 1 potion, got %d", n)
+	}
+	rec, err := db.LoadProfile(ctx, "player-1")
+	if err != nil || rec.Gold != stats.Gold || stats.Gold != 100-find(ItemHPPotion).Price {
+		t.Fatalf("expected persisted gold %d, got %d (%v)", stats.Gold, rec.Gold, err)
+	}
+
+	// Streak freezes stop selling at the carry limit.
+	if err := db.AddItems(ctx, "player-1", ItemStreakFreeze, MaxStreakFreezes); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+	if _, err := Buy(ctx, stats, find(ItemStreakFreeze)); !errors.Is(err, db.ErrItemLimit) {
+		t.Fatalf("expected the freeze limit to apply, got %v", err)
+	}
+	if rec, _ := db.LoadProfile(ctx, "player-1"); rec.Gold != stats.Gold {
+		t.Fatalf("expected a refused purchase to keep gold %d, got %d", stats.Gold, rec.Gold)
+	}
+}
+
+func TestShop_CatalogMatchesStreakFreezeLimit(t *testing.T) {
+	it, ok := ShopItemByID(ItemStreakFreeze)
+	if !ok || it.Limit != MaxStreakFreezes {
+		t.Fatalf("expected the shop to cap freezes at %d, got %+v", MaxStreakFreezes, it)
+	}
+	if p, ok := ShopItemByID(ItemHPPotion); !ok || p.Heal <= 0 {
+		t.Fatalf("expected the potion to heal, got %+v", p)
+	}
+}
+
+func TestPotion_PreventsFaint(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "potion.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	stats := DefaultStats()
+	stats.Class = "Grammar Mage"
+	stats.HP = 1
+	answers := []VocabAnswer{{Correct: false}, {Correct: true}, {Correct: true}, {Correct: true}, {Correct: true}}
+	if _, summary, _ := RunVocabSession(ctx, stats, answers); !summary.Fainted {
+		t.Fatalf("expected a faint without potions, got %+v", summary)
+	}
+
+	if err := db.AddItems(ctx, "player-1", ItemHPPotion, 1); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+	updated, summary, _ := RunVocabSession(ctx, stats, answers)
+	if summary.Fainted || summary.PotionsUsed != 1 || updated.HP <= 0 {
+		t.Fatalf("expected the potion to keep the player standing, got %+v (HP %d)", summary, updated.HP)
+	}
+	if n, _ := HPPotions(ctx); n != 0 {
+		t.Fatalf("expected the potion to be used up, got %d", n)
+	}
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// FastAnswerTime is the response time at or under which a correct answer
+	// counts as fast. The spec paces a session at 5 questions in 2 minutes
+	// (24s each); a fast answer takes a third of that.
+	FastAnswerTime = 8 * time.Second
+	// speedExpBonus is the extra share of a question's EXP a fast answer
+	// earns.
+	speedExpBonus = 0.5
+)
+
+// IsFast reports whether an answer given after latency earns the speed
+// bonus. Answers without a recorded latency never do.
+func IsFast(latency time.Duration) bool {
+	return latency > 0 && latency <= FastAnswerTime
+}
+
+// speedExp adds the speed bonus to the EXP of a correct answer.
+func speedExp(qexp int, latency time.Duration) int {
+	if !IsFast(latency) {
+		return qexp
+	}
+	return int(math.Round(float64(qexp) * (1 + speedExpBonus)))
+}
+
+// comboAfterCorrect raises combo for a correct answer given after latency;
+// fast answers count double.
+func comboAfterCorrect(combo int, latency time.Duration) int {
+	combo = AddCombo(Stats{Combo: combo}).Combo
+	if IsFast(latency) {
+		combo++
+	}
+	return combo
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIsFast(t *testing.T) {
+	cases := []struct {
+		latency time.Duration
+		want    bool
+	}{
+		{0, false}, // not measured
+		{2 * time.Second, true},
+		{FastAnswerTime, true},
+		{FastAnswerTime + time.Millisecond, false},
+	}
+	for _, c := range cases {
+		if got := IsFast(c.latency); got != c.want {
+			t.Errorf("IsFast(%v) = %v, want %v", c.latency, got, c.want)
+		}
+	}
+}
+
+func TestRunVocabSession_FastAnswersBoostExpAndCombo(t *testing.T) {
+	stats := DefaultStats()
+	stats.Class = "Grammar Mage" // keep the class bonus out of vocab
+	answer := func(latency time.Duration) []VocabAnswer {
+		a := make([]VocabAnswer, 5)
+		for i := range a {
+			a[i] = VocabAnswer{Correct: true, Item: QuestionLog{Latency: latency}}
+		}
+		return a
+	}
+
+	_, slow, _ := RunVocabSession(context.Background(), stats, answer(20*time.Second))
+	_, fast, _ := RunVocabSession(context.Background(), stats, answer(3*time.Second))
+	if slow.FastAnswers != 0 || fast.FastAnswers != 5 {
+		t.Fatalf("expected 0 and 5 fast answers, got %d and %d", slow.FastAnswers, fast.FastAnswers)
+	}
+	if fast.ExpDelta <= slow.ExpDelta {
+		t.Fatalf("expected fast answers to earn more EXP, got %d vs %d", fast.ExpDelta, slow.ExpDelta)
+	}
+	if slow.BestCombo != 5 || fast.BestCombo != 10 {
+		t.Fatalf("expected fast answers to double the combo, got %d vs %d", fast.BestCombo, slow.BestCombo)
+	}
+}
+
+func TestRunGrammarSession_FastAnswersBoostCombo(t *testing.T) {
+	stats := DefaultStats()
+	answer := func(latency time.Duration) []GrammarAnswer {
+		a := make([]GrammarAnswer, 5)
+		for i := range a {
+			a[i] = GrammarAnswer{Correct: true, Item: QuestionLog{Latency: latency}}
+		}
+		return a
+	}
+
+	slowStats, slow, _ := RunGrammarSession(context.Background(), stats, answer(20*time.Second))
+	_, fast, _ := RunGrammarSession(context.Background(), stats, answer(3*time.Second))
+	if slow.BestCombo != 5 || fast.BestCombo != 10 {
+		t.Fatalf("expected fast answers to double the combo, got %d vs %d", fast.BestCombo, slow.BestCombo)
+	}
+	if slowStats.Combo != 5 {
+		t.Fatalf("expected the combo to carry over, got %d", slowStats.Combo)
+	}
+
+	missed := answer(3 * time.Second)
+	missed[4].Correct = false
+	after, _, _ := RunGrammarSession(context.Background(), stats, missed)
+	if after.Combo != 0 {
+		t.Fatalf("expected a miss to reset the combo, got %d", after.Combo)
+	}
+}
+
+func TestRunBossSession_FastAnswersBoostCombo(t *testing.T) {
+	boss := Bosses[0]
+	stats := DefaultStats()
+	stats.Level = boss.MinLevel
+	stats.Next = ExpToNext(stats.Level)
+	answers := []BossAnswer{
+		{Correct: true, Item: QuestionLog{Latency: 3 * time.Second}},
+		{Correct: true, Item: QuestionLog{Latency: 20 * time.Second}},
+	}
+	_, summary, err := RunBossSession(context.Background(), stats, boss, len(answers), answers)
+	if err != nil {
+		t.Fatalf("RunBossSession error: %v", err)
+	}
+	if summary.FastAnswers != 1 || summary.BestCombo != 3 {
+		t.Fatalf("expected one fast answer worth two combo, got %d fast and combo %d", summary.FastAnswers, summary.BestCombo)
+	}
+}
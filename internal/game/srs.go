@@ -0,0 +1,179 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+// SM-2 spaced repetition constants.
+const (
+	SRSInitialEase = 2.5
+	SRSMinEase     = 1.3
+)
+
+// ReviewModes lists the modes whose missed items enter the review queue.
+var ReviewModes = []string{"vocab", "grammar", "spelling"}
+
+// ReviewCard holds the SM-2 scheduling state of one item.
+type ReviewCard struct {
+	Ease         float64
+	IntervalDays int
+	Repetitions  int
+	Lapses       int
+	Due          time.Time
+}
+
+// NewReviewCard returns a card that is due immediately.
+func NewReviewCard(now time.Time) ReviewCard {
+	return ReviewCard{Ease: SRSInitialEase, Due: now}
+}
+
+// Review applies an SM-2 grade (0-5, 3 or more is a pass) answered at now.
+func (c ReviewCard) Review(quality int, now time.Time) ReviewCard {
+	if quality < 0 {
+		quality = 0
+	}
+	if quality > 5 {
+		quality = 5
+	}
+	if quality < 3 {
+		c.Repetitions = 0
+		c.IntervalDays = 1
+		c.Lapses++
+	} else {
+		c.Repetitions++
+		switch c.Repetitions {
+		case 1:
+			c.IntervalDays = 1
+		case 2:
+			c.IntervalDays = 6
+		default:
+			c.IntervalDays = int(math.Round(float64(c.IntervalDays) * c.Ease))
+		}
+	}
+	miss := float64(5 - quality)
+	c.Ease += 0.1 - miss*(0.08+miss*0.02)
+	if c.Ease < SRSMinEase {
+		c.Ease = SRSMinEase
+	}
+	c.Due = now.AddDate(0, 0, c.IntervalDays)
+	return c
+}
+
+// ReviewItem is a due question ready to be asked again.
+type ReviewItem struct {
+	Mode string
+	Key  string
+	Item QuestionLog
+}
+
+type reviewRunKey struct{}
+
+// WithReview marks the sessions run with the returned context as review runs
+// of due items. A review run earns the EXP of its correct answers but no
+// clear or perfect bonus, never counts as cleared, and is saved with
+// db.SessionRecord.Review set so accuracy figures can leave it out.
+func WithReview(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reviewRunKey{}, true)
+}
+
+func isReview(ctx context.Context) bool {
+	review, _ := ctx.Value(reviewRunKey{}).(bool)
+	return review
+}
+
+// reviewKey identifies an item independently of the session it came from.
+func reviewKey(q QuestionLog) string {
+	return strings.ToLower(strings.TrimSpace(q.Prompt)) + "\x1f" + strings.ToLower(strings.TrimSpace(q.CorrectAnswer))
+}
+
+// reviewQuality maps a logged answer onto the SM-2 grade scale.
+func reviewQuality(it db.SessionItem) int {
+	switch {
+	case it.Correct:
+		return 4
+	case it.Outcome == SpellingNear.String():
+		return 2
+	default:
+		return 1
+	}
+}
+
+func isReviewMode(mode string) bool {
+	for _, m := range ReviewModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleReviews enqueues missed items and regrades items already in the queue.
+func scheduleReviews(ctx context.Context, items []db.SessionItem, now time.Time) {
+	for _, it := range items {
+		if it.PlayerID == "" || it.Prompt == "" || !isReviewMode(it.Mode) {
+			continue
+		}
+		q := QuestionLog{Prompt: it.Prompt, Options: it.Options, CorrectAnswer: it.CorrectAnswer, Explanation: it.Explanation}
+		key := reviewKey(q)
+		rec, found, err := db.GetReviewItem(ctx, it.PlayerID, it.Mode, key)
+		if err != nil {
+			log.Printf("failed to load review item: %v", err)
+			continue
+		}
+		quality := reviewQuality(it)
+		var card ReviewCard
+		if found {
+			card = ReviewCard{Ease: rec.Ease, IntervalDays: rec.IntervalDays, Repetitions: rec.Repetitions, Lapses: rec.Lapses, Due: rec.DueAt}
+			card = card.Review(quality, now)
+			rec.LastReviewedAt = now
+		} else {
+			if quality >= 3 {
+				continue
+			}
+			// A fresh miss is due right away so the shrine can offer it next.
+			card = NewReviewCard(now)
+			card.Lapses = 1
+			rec = db.ReviewItemRecord{PlayerID: it.PlayerID, Mode: it.Mode, ItemKey: key}
+		}
+		payload, err := json.Marshal(q)
+		if err != nil {
+			log.Printf("failed to encode review item: %v", err)
+			continue
+		}
+		rec.Payload = string(payload)
+		rec.Ease, rec.IntervalDays, rec.Repetitions, rec.Lapses, rec.DueAt = card.Ease, card.IntervalDays, card.Repetitions, card.Lapses, card.Due
+		if err := db.SaveReviewItem(ctx, rec); err != nil {
+			log.Printf("failed to save review item: %v", err)
+		}
+	}
+}
+
+// DueReviews returns up to limit items of mode due for the current profile.
+func DueReviews(ctx context.Context, mode string, limit int) ([]ReviewItem, error) {
+	recs, err := db.ListDueReviewItems(ctx, db.CurrentProfileID(), mode, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ReviewItem, 0, len(recs))
+	for _, rec := range recs {
+		var q QuestionLog
+		if err := json.Unmarshal([]byte(rec.Payload), &q); err != nil {
+			return nil, fmt.Errorf("invalid review item %q: %w", rec.ItemKey, err)
+		}
+		items = append(items, ReviewItem{Mode: rec.Mode, Key: rec.ItemKey, Item: q})
+	}
+	return items, nil
+}
+
+// DueReviewCounts returns how many items are due per review mode.
+func DueReviewCounts(ctx context.Context) (map[string]int, error) {
+	return db.CountDueReviewItems(ctx, db.CurrentProfileID(), time.Now())
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestReviewCard_SM2Intervals(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	c := NewReviewCard(now)
+	want := []int{1, 6, 15}
+	for i, w := range want {
+		c = c.Review(4, now)
+		if c.IntervalDays != w {
+			t.Fatalf("review %d: expected interval %d, got %d", i+1, w, c.IntervalDays)
+		}
+	}
+	if !c.Due.Equal(now.AddDate(0, 0, 15)) {
+		t.Fatalf("unexpected due date %v", c.Due)
+	}
+
+	c = c.Review(1, now)
+	if c.Repetitions != 0 || c.IntervalDays != 1 || c.Lapses != 1 {
+		t.Fatalf("expected lapse to reset the card, got %+v", c)
+	}
+	if c.Ease < SRSMinEase {
+		t.Fatalf("ease fell below minimum: %v", c.Ease)
+	}
+}
+
+func TestRunSpellingSession_EnqueuesMisses(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "srs.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	answers := []SpellingAnswer{
+		{Outcome: SpellingPerfect, Item: QuestionLog{Prompt: "必要な", CorrectAnswer: "necessary"}},
+		{Outcome: SpellingNear, Item: QuestionLog{Prompt: "受け取る", Chosen: "recieve", CorrectAnswer: "receive"}},
+	}
+	if _, _, err := RunSpellingSession(ctx, DefaultStats(), answers); err != nil {
+		t.Fatalf("RunSpellingSession error: %v", err)
+	}
+
+	due, err := DueReviews(ctx, "spelling", 10)
+	if err != nil {
+		t.Fatalf("DueReviews error: %v", err)
+	}
+	if len(due) != 1 || due[0].Item.CorrectAnswer != "receive" {
+		t.Fatalf("expected only the missed word to be due, got %+v", due)
+	}
+
+	// Answering it correctly in a review pushes it out of the due queue.
+	review := []SpellingAnswer{{Outcome: SpellingPerfect, Item: due[0].Item}}
+	if _, _, err := RunSpellingSession(ctx, DefaultStats(), review); err != nil {
+		t.Fatalf("RunSpellingSession error: %v", err)
+	}
+	counts, err := DueReviewCounts(ctx)
+	if err != nil {
+		t.Fatalf("DueReviewCounts error: %v", err)
+	}
+	if counts["spelling"] != 0 {
+		t.Fatalf("expected no due spelling reviews after a correct review, got %d", counts["spelling"])
+	}
+}
+
+func TestReviewRun_EarnsAnswerExpOnlyAndIsFlagged(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "review_run.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	answers := []VocabAnswer{{Correct: true}, {Correct: true}}
+	_, normal, _ := RunVocabSession(ctx, DefaultStats(), answers)
+	_, review, _ := RunVocabSession(WithReview(ctx), DefaultStats(), answers)
+	if !review.Review || review.Cleared || len(review.Unlocked) != 0 {
+		t.Fatalf("expected an uncleared review run without badges, got %+v", review)
+	}
+	if review.ExpDelta >= normal.ExpDelta || review.ExpDelta <= 0 {
+		t.Fatalf("expected review EXP below the %d of a full clear, got %d", normal.ExpDelta, review.ExpDelta)
+	}
+
+	sessions, err := db.ListSessions(ctx, "player-1", 10)
+	if err != nil || len(sessions) != 2 {
+		t.Fatalf("expected 2 saved sessions, got %d (%v)", len(sessions), err)
+	}
+	flagged := 0
+	for _, s := range sessions {
+		if s.Review {
+			flagged++
+		}
+	}
+	if flagged != 1 {
+		t.Fatalf("expected exactly the review run to be flagged, got %d", flagged)
+	}
+}
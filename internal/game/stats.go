@@ -21,8 +21,8 @@ type Stats struct {
 // DefaultStats returns initial status for a new game.
 func DefaultStats() Stats {
 	return Stats{
-		Name:            "Takuya",
-		Class:           "Vocabulary Warrior",
+		Name:            "Adventurer",
+		Class:           NoviceClass,
 		Level:           1,
 		Exp:             0,
 		Next:            30,
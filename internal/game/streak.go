@@ -0,0 +1,107 @@
+package game
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+const (
+	// ItemStreakFreeze is the player_items ID of a streak freeze. One freeze
+	// covers one missed calendar day.
+	ItemStreakFreeze = "streak_freeze"
+	// MaxStreakFreezes caps how many freezes a player can hold.
+	MaxStreakFreezes = 3
+	// streakFreezeEvery awards a freeze each time the streak reaches a
+	// multiple of this many days.
+	streakFreezeEvery = 7
+)
+
+// StreakChange describes how a session moved the daily streak.
+type StreakChange struct {
+	Before       int
+	After        int
+	FreezesUsed  int
+	FreezeEarned bool
+}
+
+// Broken reports whether the streak was reset.
+func (c StreakChange) Broken() bool {
+	return c.After < c.Before
+}
+
+// AdvanceStreak applies a session played at now to streak. last is when the
+// previous session ended (zero if there was none) and freezes is how many
+// streak freezes the player owns. Days are calendar days in now's location.
+func AdvanceStreak(streak int, last, now time.Time, freezes int) StreakChange {
+	c := StreakChange{Before: streak}
+	if last.IsZero() {
+		c.After = 1
+		return c
+	}
+	switch gap := calendarDaysBetween(last.In(now.Location()), now); {
+	case gap <= 0:
+		c.After = max(streak, 1)
+	case gap == 1:
+		c.After = streak + 1
+	case gap-1 <= freezes:
+		c.FreezesUsed = gap - 1
+		c.After = streak + 1
+	default:
+		c.After = 1
+	}
+	if c.After > c.Before && c.After%streakFreezeEvery == 0 && freezes-c.FreezesUsed < MaxStreakFreezes {
+		c.FreezeEarned = true
+	}
+	return c
+}
+
+// calendarDaysBetween counts date boundaries between a and b, ignoring the
+// time of day so DST shifts do not matter.
+func calendarDaysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// StreakFreezes returns how many streak freezes the active profile owns.
+func StreakFreezes(ctx context.Context) (int, error) {
+	return db.ItemCount(ctx, db.CurrentProfileID(), ItemStreakFreeze)
+}
+
+// updateStreak advances the active profile's streak for a session ending at
+// now and settles streak freezes. It must run before the session is saved so
+// the previous session is still the latest one.
+func updateStreak(ctx context.Context, stats Stats, now time.Time) (Stats, StreakChange) {
+	unchanged := StreakChange{Before: stats.Streak, After: stats.Streak}
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return stats, unchanged
+	}
+	last, _, err := db.LastSessionEndedAt(ctx, playerID)
+	if err != nil {
+		log.Printf("failed to load last session: %v", err)
+		return stats, unchanged
+	}
+	freezes, err := db.ItemCount(ctx, playerID, ItemStreakFreeze)
+	if err != nil {
+		log.Printf("failed to load streak freezes: %v", err)
+	}
+
+	change := AdvanceStreak(stats.Streak, last, now, freezes)
+	delta := -change.FreezesUsed
+	if change.FreezeEarned {
+		delta++
+	}
+	if delta != 0 {
+		if err := db.AddItems(ctx, playerID, ItemStreakFreeze, delta); err != nil {
+			log.Printf("failed to update streak freezes: %v", err)
+		}
+	}
+	stats.Streak = change.After
+	return stats, change
+}
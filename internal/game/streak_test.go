@@ -0,0 +1,81 @@
+package game
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestAdvanceStreak_CalendarDays(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2025, 3, 10, 0, 30, 0, 0, tokyo)
+
+	cases := []struct {
+		name    string
+		streak  int
+		last    time.Time
+		freezes int
+		want    StreakChange
+	}{
+		{"first session", 0, time.Time{}, 0, StreakChange{Before: 0, After: 1}},
+		{"same day", 4, time.Date(2025, 3, 10, 0, 5, 0, 0, tokyo), 0, StreakChange{Before: 4, After: 4}},
+		// 23:50 JST the previous evening is 14:50 UTC on the 9th; the local
+		// calendar decides, not the 40 minutes that passed.
+		{"next day", 4, time.Date(2025, 3, 9, 14, 50, 0, 0, time.UTC), 0, StreakChange{Before: 4, After: 5}},
+		{"missed day without freeze", 4, time.Date(2025, 3, 8, 12, 0, 0, 0, tokyo), 0, StreakChange{Before: 4, After: 1}},
+		{"missed days covered by freezes", 4, time.Date(2025, 3, 7, 12, 0, 0, 0, tokyo), 2, StreakChange{Before: 4, After: 5, FreezesUsed: 2}},
+		{"weekly freeze", 6, time.Date(2025, 3, 9, 20, 0, 0, 0, tokyo), 0, StreakChange{Before: 6, After: 7, FreezeEarned: true}},
+		{"freeze cap", 13, time.Date(2025, 3, 9, 20, 0, 0, 0, tokyo), MaxStreakFreezes, StreakChange{Before: 13, After: 14}},
+	}
+	for _, tc := range cases {
+		if got := AdvanceStreak(tc.streak, tc.last, now, tc.freezes); got != tc.want {
+			t.Errorf("%s: expected %+v, got %+v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestRunVocabSession_UpdatesStreakAndFreezes(t *testing.T) {
+	if err := db.InitDB(filepath.Join(t.TempDir(), "streak.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	ctx := context.Background()
+
+	// The last session was three days ago; one freeze is not enough to cover
+	// the two missed days.
+	ended := time.Now().AddDate(0, 0, -3)
+	rec := db.NewSessionRecord("vocab", ended.Add(-time.Minute), ended)
+	rec.PlayerID = "player-1"
+	if err := db.SaveSession(ctx, rec); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+	if err := db.AddItems(ctx, "player-1", ItemStreakFreeze, 1); err != nil {
+		t.Fatalf("AddItems error: %v", err)
+	}
+
+	stats := DefaultStats()
+	stats.Streak = 9
+	updated, summary, err := RunVocabSession(ctx, stats, []VocabAnswer{{Correct: true}})
+	if err != nil {
+		t.Fatalf("RunVocabSession error: %v", err)
+	}
+	if !summary.Streak.Broken() || updated.Streak != 1 {
+		t.Fatalf("expected the streak to reset, got %+v (stats %d)", summary.Streak, updated.Streak)
+	}
+	if n, _ := StreakFreezes(ctx); n != 1 {
+		t.Fatalf("an insufficient freeze must not be consumed, got %d left", n)
+	}
+
+	// A second session today keeps the streak.
+	updated, summary, _ = RunVocabSession(ctx, updated, []VocabAnswer{{Correct: true}})
+	if summary.Streak.Before != 1 || updated.Streak != 1 {
+		t.Fatalf("expected the same-day streak to stay at 1, got %+v", summary.Streak)
+	}
+	if rec, err := db.LoadProfile(ctx, "player-1"); err != nil || rec.StreakDays != 1 {
+		t.Fatalf("expected the streak to be persisted, got %d (%v)", rec.StreakDays, err)
+	}
+}
@@ -49,17 +49,39 @@ func T(key string) string {
 var en = map[string]string{
 	"menu_start":                      "Start Adventure",
 	"menu_new":                        "New Game",
+	"menu_profiles":                   "Switch Profile",
 	"menu_quit":                       "Quit",
 	"note_newgame":                    "Press N to start a new game",
-	"note_confirm_newgame":            "Starting a new game resets progress. Proceed? [y/n]",
+	"note_confirm_newgame":            "Starting a new game erases this profile's progress, items, equipment, achievements and history. Proceed? [y/n]",
 	"app_title":                       "TUI English Quest",
-	"footer_main":                     "[j/k] Move  [Enter] Select  [n] New Game  [q] Quit",
+	"footer_main":                     "[j/k] Move  [Enter] Select  [n] New Game  [p] Profiles  [q] Quit",
+	"note_profile_switched":           "Playing as %s.",
+	"profiles_title":                  "Who is playing?",
+	"profiles_line_format":            "%s  Lv.%d %s",
+	"profiles_active":                 "(current)",
+	"profiles_new":                    "+ New profile",
+	"profiles_delete_confirm":         "Delete %s with all of their history? [y/n]",
+	"profiles_cannot_delete_active":   "Switch to another profile before deleting this one.",
+	"profiles_error":                  "Profile error: %v",
+	"footer_profiles":                 "[j/k] Move  [Enter] Select  [n] New  [d] Delete  [Esc] Back",
+	"character_title":                 "Create your character",
+	"character_name_prompt":           "What is your name?",
+	"character_name_placeholder":      "Your name",
+	"character_name_required":         "Please enter a name.",
+	"character_class_prompt":          "Choose a class for %s:",
+	"class_desc_vocab":                "+%d%% EXP in Vocabulary Battle",
+	"class_desc_grammar":              "+%d%% EXP in Grammar Dungeon",
+	"class_desc_tavern":               "+%d%% EXP in Conversation Tavern",
+	"footer_character_name":           "[Enter] Next  [Esc] Cancel",
+	"footer_character_class":          "[j/k] Move  [Enter] Start  [Esc] Back",
 	"settings_title":                  "Settings",
 	"settings_prompt":                 "Configure application settings:",
 	"settings_menu_api":               "Set Gemini API Key",
 	"settings_menu_lang":              "Language (EN/JA)",
 	"settings_save":                   "Save and Exit",
 	"settings_menu_questions_current": "Questions per session (current: %d)",
+	"settings_menu_timer_current":     "Time limit per question (current: %ds)",
+	"settings_menu_timer_off":         "Time limit per question (current: off)",
 	"confirm_save":                    "API key has changed. Do you want to save?",
 	"confirm_save_opt1":               "Save Changes",
 	"confirm_save_opt2":               "Discard Changes",
@@ -90,6 +112,78 @@ var en = map[string]string{
 	"town_menu_conversation_tavern":   "🍺 Conversation Tavern",
 	"town_menu_spelling_challenge":    "🪄 Spelling Challenge",
 	"town_menu_listening_cave":        "🔊 Listening Cave",
+	"town_menu_review_shrine":         "⛩  Review Shrine",
+	"review_title":                    "Review Shrine",
+	"review_prompt":                   "Missed questions return here when they are due. Choose a trial:",
+	"review_due_format":               "%s (%d due)",
+	"review_nothing_due":              "Nothing is due here. Missed questions will appear after your sessions.",
+	"review_error":                    "Could not load reviews: %v",
+	"review_enemy_name":               "Shrine Spirit",
+	"footer_review":                   "[j/k] Move  [Enter] Start review  [Esc] Back to Town",
+	"town_menu_equipment":             "🛡  Equipment",
+	"equipment_title":                 "Equipment",
+	"equipment_slot_weapon":           "Weapon",
+	"equipment_slot_armor":            "Armor",
+	"equipment_slot_ring":             "Ring",
+	"equipment_slot_charm":            "Charm",
+	"equipment_empty":                 "(empty)",
+	"equipment_remove":                "(remove)",
+	"equipment_pick_prompt":           "Choose a %s:",
+	"equipment_effect_exp":            "+%d%% EXP",
+	"equipment_effect_damage":         "-%d%% damage",
+	"equipment_target_all":            "all modes",
+	"equipment_error":                 "Equipment error: %v",
+	"footer_equipment":                "[j/k] Move  [Enter] Change  [Esc] Back to Town",
+	"footer_equipment_pick":           "[j/k] Move  [Enter] Equip  [Esc] Back",
+	"town_menu_shop":                  "💰 Shop",
+	"shop_title":                      "Shop",
+	"shop_gold":                       "Gold: %d G",
+	"shop_owned":                      "(owned)",
+	"shop_bought":                     "Bought %s for %d G.",
+	"shop_not_enough_gold":            "Not enough Gold.",
+	"shop_sold_out":                   "You cannot carry any more of that.",
+	"shop_error":                      "Shop error: %v",
+	"footer_shop":                     "[j/k] Move  [Enter] Buy  [Esc] Back to Town",
+	"result_potions_used":             "Drank %d HP potion(s) to keep fighting",
+	"result_achievement_unlocked":     "🏆 Achievement unlocked: %s",
+	"result_fast_answers":             "⚡ Fast answers: %d (bonus EXP)",
+	"timer_time_up":                   "⏱ Time's up!",
+	"town_menu_boss_lair":             "👑 Boss Lair",
+	"boss_title":                      "Boss Lair",
+	"boss_intro":                      "A boss guards each tier. Defeat it with correct answers before it knocks you out.",
+	"boss_stone_golem":                "Stone Golem",
+	"boss_riddle_sphinx":              "Riddle Sphinx",
+	"boss_lich_scholar":               "Lich Scholar",
+	"boss_abyss_kraken":               "Abyss Kraken",
+	"boss_word_dragon":                "Word Dragon",
+	"boss_wins":                       "(wins: %d)",
+	"boss_desc":                       "Tier %d boss. First victory: %d EXP, %d G",
+	"boss_locked":                     "Reach level %d to challenge this boss.",
+	"boss_question_progress":          "[%s] Question %d/%d: %s",
+	"boss_hit":                        "Hit! %d damage.",
+	"boss_incorrect_answer":           "The boss strikes back! Answer: %s",
+	"boss_error":                      "Boss error: %v",
+	"footer_boss":                     "[j/k] Move  [Enter] Challenge  [Esc] Back to Town",
+	"footer_boss_battle":              "[j/k/1-4] Choose  [Enter] Answer  [Esc] Flee to Town",
+	"result_title_boss":               "Boss Battle",
+	"result_boss_defeated":            "👑 %s defeated! (victory #%d)",
+	"result_boss_survived":            "%s survived with %d/%d HP",
+	"achievement_first_clear":         "First Victory",
+	"achievement_first_clear_desc":    "Clear a session: finish without fainting, pass a tavern talk or defeat a boss",
+	"achievement_combo_10":            "Combo Master",
+	"achievement_combo_10_desc":       "Reach a 10-answer combo",
+	"achievement_perfect_run":         "Flawless",
+	"achievement_perfect_run_desc":    "Answer every question in a session correctly",
+	"achievement_streak_7":            "Week Warrior",
+	"achievement_streak_7_desc":       "Keep a 7-day streak",
+	"achievement_level_10":            "Apprentice",
+	"achievement_level_10_desc":       "Reach level 10",
+	"achievement_level_25":            "Journeyman",
+	"achievement_level_25_desc":       "Reach level 25",
+	"achievement_level_50":            "Veteran",
+	"achievement_level_50_desc":       "Reach level 50",
+	"achievement_level_100":           "Legend",
+	"achievement_level_100_desc":      "Reach level 100",
 	"town_menu_ai_analysis":           "🧠 AI Analysis",
 	"town_menu_history":               "📖 History",
 	"town_menu_status":                "🎒 Status",
@@ -112,29 +206,103 @@ var en = map[string]string{
 	"result_leveled_up":               "Level up! You feel stronger.",
 	"result_fainted":                  "Fainted. You lost some EXP.",
 	"result_note":                     "Note: %s",
+	"result_streak_up":                "Daily streak: %d → %d days",
+	"result_streak_broken":            "Streak broken after %d days. Starting again at %d.",
+	"result_streak_freeze_used":       "Used %d streak freeze(s) to keep your streak.",
+	"result_streak_freeze_earned":     "Earned a streak freeze!",
 	"result_footer":                   "Press Enter to return to Town.",
+	"tavern_npc_thinking":             "%s is thinking...",
+	"tavern_turn_feedback":            "%s — %s (Enter to continue)",
+	"tavern_live_unavailable":         "Live conversation unavailable (%v); continuing with the scripted tavern.",
+	"footer_history":                  "[j/k] Move  [h/l] Page  [m] Mode  [d] Period  [Enter] Details  [x] Export cards  [Esc] Town",
+	"footer_history_detail":           "[j/k] Scroll  [Enter/Esc] Back to list",
+	"history_title":                   "Session History",
+	"history_no_sessions":             "No sessions found.",
+	"history_cards_exported":          "Exported %d cards to %s and %s",
+	"history_cards_error":             "Could not export cards: %v",
+	"history_error":                   "Could not load history: %v",
+	"history_filter":                  "Mode: %s   Period: %s",
+	"history_mode_all":                "All",
+	"history_range_all":               "All time",
+	"history_range_today":             "Today",
+	"history_range_days":              "Last %d days",
+	"history_page":                    "Page %d/%d (%d sessions)",
+	"history_detail_summary":          "Correct %d/%d   EXP %+d   HP %+d   Gold %+d",
+	"history_detail_answer":           "Your answer: %s",
+	"history_detail_correct":          "Correct answer: %s",
+	"history_detail_no_items":         "No question log was saved for this session.",
+	"history_detail_range":            "Questions %d-%d of %d",
+	"history_review":                  "(review)",
+	"footer_analysis":                 "[c] Charts  [Enter/Esc] Back to Town",
+	"footer_analysis_charts":          "[w] Window (7/30/90 days)  [c] Report  [Enter/Esc] Back to Town",
+	"analysis_charts_title":           "Progress — last %d days",
+	"analysis_charts_totals":          "Sessions: %d   EXP: %+d",
+	"analysis_charts_empty":           "No sessions in this window.",
+	"analysis_charts_error":           "Could not load progress: %v",
+	"analysis_chart_exp":              "EXP gained per day (max %d)",
+	"analysis_chart_exp_bucket":       "EXP gained per %d days (max %d)",
+	"analysis_chart_accuracy":         "Accuracy by mode (latest)",
+	"analysis_chart_faints":           "Faints per week",
 }
 
 var ja = map[string]string{
 
-	"menu_start":           "冒険を始める",
-	"menu_new":             "新しいゲーム",
-	"menu_quit":            "終了",
-	"note_newgame":         "Nで新しいゲームを開始",
-	"note_confirm_newgame": "新しいゲームを始めると進行状況がリセットされます。よろしいですか？ [y/n]",
-	"app_title":            "TUI English Quest",
-	"footer_main":          "[j/k] 移動  [Enter] 選択  [n] 新しいゲーム  [q] 終了",
-	"settings_title":       "設定",
-	"settings_prompt":      "アプリケーション設定:",
-	"settings_menu_api":    "ジェミニAPIキー設定",
-	"settings_menu_lang":   "言語設定 (EN/JA)",
+	"menu_start":                    "冒険を始める",
+	"menu_new":                      "新しいゲーム",
+	"menu_profiles":                 "プロフィール切替",
+	"menu_quit":                     "終了",
+	"note_newgame":                  "Nで新しいゲームを開始",
+	"note_confirm_newgame":          "新しいゲームを始めると、このプロフィールの進行状況・アイテム・装備・実績・履歴が消去されます。よろしいですか？ [y/n]",
+	"app_title":                     "TUI English Quest",
+	"footer_main":                   "[j/k] 移動  [Enter] 選択  [n] 新しいゲーム  [p] プロフィール  [q] 終了",
+	"note_profile_switched":         "%s でプレイ中",
+	"profiles_title":                "だれが遊びますか？",
+	"profiles_line_format":          "%s  Lv.%d %s",
+	"profiles_active":               "（使用中）",
+	"profiles_new":                  "+ 新しいプロフィール",
+	"profiles_delete_confirm":       "%s と履歴をすべて削除しますか？ [y/n]",
+	"profiles_cannot_delete_active": "削除する前に別のプロフィールに切り替えてください。",
+	"profiles_error":                "プロフィールのエラー: %v",
+	"footer_profiles":               "[j/k] 移動  [Enter] 選択  [n] 新規  [d] 削除  [Esc] 戻る",
+	"character_title":               "キャラクター作成",
+	"character_name_prompt":         "あなたの名前は？",
+	"character_name_placeholder":    "名前",
+	"character_name_required":       "名前を入力してください。",
+	"character_class_prompt":        "%s のクラスを選んでください:",
+	"class_desc_vocab":              "単語バトルで EXP +%d%%",
+	"class_desc_grammar":            "文法ダンジョンで EXP +%d%%",
+	"class_desc_tavern":             "会話の酒場で EXP +%d%%",
+	"footer_character_name":         "[Enter] 次へ  [Esc] キャンセル",
+	"footer_character_class":        "[j/k] 移動  [Enter] 開始  [Esc] 戻る",
+	"settings_title":                "設定",
+	"settings_prompt":               "アプリケーション設定:",
+	"settings_menu_api":             "ジェミニAPIキー設定",
+	"settings_menu_lang":            "言語設定 (EN/JA)",
 
 	"settings_menu_lang_current":      "言語設定 (現在: %s)",
 	"settings_save":                   "保存して終了",
 	"settings_menu_questions_current": "1セッションの出題数 (現在: %d)",
-	"footer_history":                  "[j/k] 移動  [Enter/Esc] Townへ戻る",
+	"settings_menu_timer_current":     "1問の制限時間 (現在: %d秒)",
+	"settings_menu_timer_off":         "1問の制限時間 (現在: なし)",
+	"footer_history":                  "[j/k] 移動  [h/l] ページ  [m] モード  [d] 期間  [Enter] 詳細  [x] カードを書き出す  [Esc] Townへ戻る",
+	"footer_history_detail":           "[j/k] スクロール  [Enter/Esc] 一覧へ戻る",
 	"history_title":                   "セッション履歴",
 	"history_no_sessions":             "セッションは見つかりませんでした。",
+	"history_cards_exported":          "%d 枚のカードを %s と %s に書き出しました",
+	"history_cards_error":             "カードを書き出せませんでした: %v",
+	"history_error":                   "履歴を読み込めませんでした: %v",
+	"history_filter":                  "モード: %s   期間: %s",
+	"history_mode_all":                "すべて",
+	"history_range_all":               "全期間",
+	"history_range_today":             "今日",
+	"history_range_days":              "過去%d日",
+	"history_page":                    "%d/%d ページ（%d 件）",
+	"history_detail_summary":          "正解 %d/%d   EXP %+d   HP %+d   ゴールド %+d",
+	"history_detail_answer":           "あなたの回答: %s",
+	"history_detail_correct":          "正解: %s",
+	"history_detail_no_items":         "このセッションの問題ログは保存されていません。",
+	"history_detail_range":            "%d〜%d 問目 / 全 %d 問",
+	"history_review":                  "（復習）",
 	"analysis_title":                  "AI 分析",
 	"analysis_recent_performance":     "直近のパフォーマンス (直近200問)",
 	"analysis_summary":                "要約",
@@ -144,7 +312,16 @@ var ja = map[string]string{
 	"analysis_weak_points":            "弱点:",
 	"analysis_strengths":              "強み:",
 	"analysis_recommendations":        "推奨:",
-	"footer_analysis":                 "[Enter] OK  [Esc] Back to Town",
+	"footer_analysis":                 "[c] グラフ  [Enter/Esc] Townへ戻る",
+	"footer_analysis_charts":          "[w] 期間 (7/30/90日)  [c] レポート  [Enter/Esc] Townへ戻る",
+	"analysis_charts_title":           "進捗 — 過去%d日",
+	"analysis_charts_totals":          "セッション: %d   EXP: %+d",
+	"analysis_charts_empty":           "この期間のセッションはありません。",
+	"analysis_charts_error":           "進捗を読み込めませんでした: %v",
+	"analysis_chart_exp":              "1日ごとの獲得EXP（最大 %d）",
+	"analysis_chart_exp_bucket":       "%d日ごとの獲得EXP（最大 %d）",
+	"analysis_chart_accuracy":         "モード別の正答率（右端が最新）",
+	"analysis_chart_faints":           "週ごとの気絶回数",
 	"confirm_save":                    "APIキーが変更されました。保存しますか?",
 	"confirm_save_opt1":               "変更を保存",
 	"confirm_save_opt2":               "変更を破棄",
@@ -190,6 +367,9 @@ var ja = map[string]string{
 	"tavern_npc_line":           "NPC (%s): %s",
 	"tavern_player_turn":        "あなたの番 (%d/%d):\n%s",
 	"tavern_eval_line":          "Turn %d: %s — %s",
+	"tavern_npc_thinking":       "%s が考えています...",
+	"tavern_turn_feedback":      "%s — %s（Enter で続ける）",
+	"tavern_live_unavailable":   "ライブ会話を利用できません（%v）。用意された会話で続けます。",
 
 	// Town / Menu related translations (added)
 	"town_menu_vocab_battle":        "⚔  単語バトル",
@@ -197,6 +377,78 @@ var ja = map[string]string{
 	"town_menu_conversation_tavern": "🍺 会話の酒場",
 	"town_menu_spelling_challenge":  "🪄 スペルチャレンジ",
 	"town_menu_listening_cave":      "🔊 リスニング問題",
+	"town_menu_review_shrine":       "⛩  復習の社",
+	"review_title":                  "復習の社",
+	"review_prompt":                 "間違えた問題は復習の時期になるとここに戻ってきます。試練を選んでください:",
+	"review_due_format":             "%s（復習 %d 件）",
+	"review_nothing_due":            "今は復習する問題がありません。間違えた問題はセッション後にここに現れます。",
+	"review_error":                  "復習データを読み込めませんでした: %v",
+	"review_enemy_name":             "社の精霊",
+	"footer_review":                 "[j/k] 移動  [Enter] 復習開始  [Esc] Townへ戻る",
+	"town_menu_equipment":           "🛡  装備",
+	"equipment_title":               "装備",
+	"equipment_slot_weapon":         "武器",
+	"equipment_slot_armor":          "防具",
+	"equipment_slot_ring":           "指輪",
+	"equipment_slot_charm":          "お守り",
+	"equipment_empty":               "（なし）",
+	"equipment_remove":              "（外す）",
+	"equipment_pick_prompt":         "%sを選んでください:",
+	"equipment_effect_exp":          "EXP +%d%%",
+	"equipment_effect_damage":       "ダメージ -%d%%",
+	"equipment_target_all":          "全モード",
+	"equipment_error":               "装備のエラー: %v",
+	"footer_equipment":              "[j/k] 移動  [Enter] 変更  [Esc] 街に戻る",
+	"footer_equipment_pick":         "[j/k] 移動  [Enter] 装備  [Esc] 戻る",
+	"town_menu_shop":                "💰 ショップ",
+	"shop_title":                    "ショップ",
+	"shop_gold":                     "所持金: %d G",
+	"shop_owned":                    "（所持）",
+	"shop_bought":                   "%sを%d Gで買いました。",
+	"shop_not_enough_gold":          "Goldが足りません。",
+	"shop_sold_out":                 "これ以上は持てません。",
+	"shop_error":                    "ショップのエラー: %v",
+	"footer_shop":                   "[j/k] 移動  [Enter] 購入  [Esc] 街に戻る",
+	"result_potions_used":           "HPポーションを%d個使って持ちこたえました",
+	"result_achievement_unlocked":   "🏆 実績解除: %s",
+	"result_fast_answers":           "⚡ 素早い回答: %d 問（EXP ボーナス）",
+	"timer_time_up":                 "⏱ 時間切れ！",
+	"town_menu_boss_lair":           "👑 ボスの間",
+	"boss_title":                    "ボスの間",
+	"boss_intro":                    "各ティアの境目にはボスが待ち構えています。倒される前に正解で攻撃しましょう。",
+	"boss_stone_golem":              "ストーンゴーレム",
+	"boss_riddle_sphinx":            "謎かけスフィンクス",
+	"boss_lich_scholar":             "賢者リッチ",
+	"boss_abyss_kraken":             "深淵のクラーケン",
+	"boss_word_dragon":              "言葉の竜",
+	"boss_wins":                     "（勝利: %d）",
+	"boss_desc":                     "ティア%dのボス。初勝利報酬: %d EXP, %d G",
+	"boss_locked":                   "レベル%dで挑戦できます。",
+	"boss_question_progress":        "[%s] 第%d問/%d: %s",
+	"boss_hit":                      "命中！ %dダメージ。",
+	"boss_incorrect_answer":         "ボスの反撃！ 正解: %s",
+	"boss_error":                    "ボスのエラー: %v",
+	"footer_boss":                   "[j/k] 移動  [Enter] 挑戦  [Esc] 街に戻る",
+	"footer_boss_battle":            "[j/k/1-4] 選択  [Enter] 回答  [Esc] 街へ逃げる",
+	"result_title_boss":             "ボスバトル",
+	"result_boss_defeated":          "👑 %sを倒した！（%d勝目）",
+	"result_boss_survived":          "%sはHP %d/%dで生き残った",
+	"achievement_first_clear":       "初勝利",
+	"achievement_first_clear_desc":  "セッションをクリアする（戦闘不能にならずに終える・酒場の会話に合格する・ボスを倒す）",
+	"achievement_combo_10":          "コンボマスター",
+	"achievement_combo_10_desc":     "10 コンボを達成する",
+	"achievement_perfect_run":       "パーフェクト",
+	"achievement_perfect_run_desc":  "セッションの全問に正解する",
+	"achievement_streak_7":          "一週間の戦士",
+	"achievement_streak_7_desc":     "7 日連続ストリークを達成する",
+	"achievement_level_10":          "見習い",
+	"achievement_level_10_desc":     "レベル 10 に到達する",
+	"achievement_level_25":          "一人前",
+	"achievement_level_25_desc":     "レベル 25 に到達する",
+	"achievement_level_50":          "ベテラン",
+	"achievement_level_50_desc":     "レベル 50 に到達する",
+	"achievement_level_100":         "伝説",
+	"achievement_level_100_desc":    "レベル 100 に到達する",
 	"town_menu_ai_analysis":         "🧠 AI 分析",
 	"town_menu_history":             "📖 履歴",
 	"town_menu_status":              "🎒 ステータス",
@@ -218,6 +470,10 @@ var ja = map[string]string{
 	"result_leveled_up":             "レベルアップ！強くなった気がする。",
 	"result_fainted":                "気絶しました。経験値を少し失いました。",
 	"result_note":                   "備考: %s",
+	"result_streak_up":              "連続日数: %d → %d 日",
+	"result_streak_broken":          "%d 日の連続記録が途切れました。%d 日目から再スタート。",
+	"result_streak_freeze_used":     "ストリークフリーズを %d 個使って記録を守りました。",
+	"result_streak_freeze_earned":   "ストリークフリーズを獲得しました！",
 	"result_footer":                 "EnterでTownに戻る。",
 }
 
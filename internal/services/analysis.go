@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -10,7 +11,6 @@ import (
 )
 
 const (
-	questionsPerSession    = 5
 	recentWindowSessions   = 5
 	previousWindowSessions = 5
 )
@@ -39,6 +39,7 @@ type WeaknessReport struct {
 	Recommendation string
 	Summary        string
 	ActionPlan     []ActionSuggestion
+	Sessions       int // number of sessions analyzed
 }
 
 type modeAccum struct {
@@ -53,7 +54,7 @@ type modeAccum struct {
 }
 
 // AnalyzeWeakness analyzes player history and generates a weakness report.
-func AnalyzeWeakness(ctx context.Context, gc *GeminiClient, playerID string, stats game.Stats, historyLimit int) (WeaknessReport, error) {
+func AnalyzeWeakness(ctx context.Context, p Provider, playerID string, stats game.Stats, historyLimit int) (WeaknessReport, error) {
 	sessions, err := db.ListSessions(ctx, playerID, historyLimit)
 	if err != nil {
 		return WeaknessReport{
@@ -72,28 +73,34 @@ func AnalyzeWeakness(ctx context.Context, gc *GeminiClient, playerID string, sta
 	}
 
 	accum := map[string]*modeAccum{}
-	totalCorrect, totalQuestions := 0, 0
-	recentEnd := min(len(sessions), recentWindowSessions)
-	prevEnd := min(len(sessions), recentWindowSessions+previousWindowSessions)
+	analyzed, totalCorrect, totalQuestions := 0, 0, 0
 
-	for i, session := range sessions {
+	// Boss battles ask a varying number of questions from two modes, review
+	// runs only replay due items, and sessions without a question log cannot
+	// be measured.
+	for _, session := range sessions {
+		if session.Mode == "boss" || session.Review || session.QuestionCount == 0 {
+			continue
+		}
+		asked := session.QuestionCount
 		totalCorrect += session.CorrectCount
-		totalQuestions += questionsPerSession
+		totalQuestions += asked
 		ma := accum[session.Mode]
 		if ma == nil {
 			ma = &modeAccum{Mode: session.Mode}
 			accum[session.Mode] = ma
 		}
 		ma.Sessions++
-		ma.Total += questionsPerSession
+		ma.Total += asked
 		ma.Correct += session.CorrectCount
-		if i < recentEnd {
-			ma.RecentTotal += questionsPerSession
+		if analyzed < recentWindowSessions {
+			ma.RecentTotal += asked
 			ma.RecentCorrect += session.CorrectCount
-		} else if i < prevEnd {
-			ma.PrevTotal += questionsPerSession
+		} else if analyzed < recentWindowSessions+previousWindowSessions {
+			ma.PrevTotal += asked
 			ma.PrevCorrect += session.CorrectCount
 		}
+		analyzed++
 	}
 
 	insights := make([]ModeInsight, 0, len(accum))
@@ -106,7 +113,7 @@ func AnalyzeWeakness(ctx context.Context, gc *GeminiClient, playerID string, sta
 	})
 
 	weakPoints, strengthPoints, recommendation := buildWeakAndStrong(insights)
-	summary := buildSummary(len(sessions), totalCorrect, totalQuestions)
+	summary := buildSummary(analyzed, totalCorrect, totalQuestions)
 	actionPlan := buildActionPlan(stats, weakPoints, strengthPoints)
 
 	return WeaknessReport{
@@ -115,9 +122,33 @@ func AnalyzeWeakness(ctx context.Context, gc *GeminiClient, playerID string, sta
 		Recommendation: recommendation,
 		Summary:        summary,
 		ActionPlan:     actionPlan,
+		Sessions:       analyzed,
 	}, nil
 }
 
+// RecordAnalysis saves report to the player's analysis history. Reports
+// without any analyzed session are not kept.
+func RecordAnalysis(ctx context.Context, playerID string, report WeaknessReport) error {
+	if report.Sessions == 0 {
+		return nil
+	}
+	weak, err := json.Marshal(report.WeakPoints)
+	if err != nil {
+		return fmt.Errorf("failed to encode weak points: %w", err)
+	}
+	strong, err := json.Marshal(report.StrengthPoints)
+	if err != nil {
+		return fmt.Errorf("failed to encode strengths: %w", err)
+	}
+	return db.SaveAnalysis(ctx, db.AnalysisRecord{
+		PlayerID:       playerID,
+		AnalyzedRange:  report.Sessions,
+		WeakPoints:     string(weak),
+		StrengthPoints: string(strong),
+		Recommendation: report.Recommendation,
+	})
+}
+
 func buildModeInsight(acc *modeAccum) ModeInsight {
 	accuracy := 0.0
 	if acc.Total > 0 {
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+)
+
+func TestAnalyzeWeakness_MeasuresAskedQuestions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "analysis.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Date(2026, 6, 30, 18, 0, 0, 0, time.UTC)
+	save := func(id, mode string, minutesAgo, correct, asked int, review bool) {
+		ended := now.Add(-time.Duration(minutesAgo) * time.Minute)
+		rec := db.SessionRecord{ID: id, PlayerID: "p1", Mode: mode, StartedAt: ended, EndedAt: ended, Review: review}
+		saveSessionWithItems(t, rec, correct, asked)
+	}
+	save("a", "boss", 0, 14, 20, false)
+	save("b", ModeVocab, 1, 9, 10, false)
+	save("c", ModeGrammar, 2, 1, 4, false)
+	save("d", ModeListening, 3, 0, 0, false) // no question log to measure
+	save("e", ModeVocab, 4, 0, 2, true)      // review run of two due items
+
+	report, err := AnalyzeWeakness(ctx, nil, "p1", game.Stats{}, 20)
+	if err != nil {
+		t.Fatalf("AnalyzeWeakness error: %v", err)
+	}
+	if report.Sessions != 2 {
+		t.Fatalf("expected 2 analyzed sessions, got %d", report.Sessions)
+	}
+	if len(report.StrengthPoints) != 1 || report.StrengthPoints[0].Mode != ModeVocab || report.StrengthPoints[0].Accuracy != 0.9 {
+		t.Fatalf("expected vocab at 90%% as the only strength, got %+v", report.StrengthPoints)
+	}
+	if len(report.WeakPoints) != 1 || report.WeakPoints[0].Mode != ModeGrammar || report.WeakPoints[0].Accuracy != 0.25 {
+		t.Fatalf("expected grammar at 25%% as the only weak point, got %+v", report.WeakPoints)
+	}
+	if report.Summary != "Analyzed 2 sessions (14 questions) with 71% accuracy overall." {
+		t.Fatalf("unexpected summary %q", report.Summary)
+	}
+}
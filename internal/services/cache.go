@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"tui-english-quest/internal/db"
+)
+
+// Prefetcher is implemented by providers that can fetch the next session's
+// questions ahead of time.
+type Prefetcher interface {
+	Prefetch(ctx context.Context, req QuestionRequest) error
+}
+
+// CachedProvider stores validated question sets in SQLite. Sessions consume
+// prefetched sets first, and previously served sets are replayed when the
+// wrapped backend fails.
+type CachedProvider struct {
+	next Provider
+}
+
+// NewCachedProvider wraps next with the persistent question cache.
+func NewCachedProvider(next Provider) *CachedProvider {
+	return &CachedProvider{next: next}
+}
+
+func cacheKey(req QuestionRequest) db.QuestionSetKey {
+	return db.QuestionSetKey{Mode: req.Mode, Lang: req.Lang, Count: req.Count, CEFR: req.CEFR}
+}
+
+// FetchQuestions serves a prefetched set when one is waiting, otherwise fetches
+// from the backend and caches the result.
+func (c *CachedProvider) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	if len(req.Exclude) > 0 || req.Hard {
+		// Follow-up requests for a partial set and boss sets are never cached.
+		return fetchRepaired(ctx, c.next, req)
+	}
+	key := cacheKey(req)
+	if content, ok, err := db.TakeQuestionSet(ctx, key); err != nil {
+		log.Printf("question cache lookup failed: %v", err)
+	} else if ok {
+		return QuestionPayload{Mode: req.Mode, Content: content}, nil
+	}
+
+	payload, err := c.fetchValid(ctx, req)
+	if err == nil {
+		if err := db.SaveQuestionSet(ctx, key, payload.Content, true); err != nil {
+			log.Printf("failed to cache questions: %v", err)
+		}
+		return payload, nil
+	}
+
+	content, ok, cacheErr := db.ReuseQuestionSet(ctx, key)
+	if cacheErr != nil {
+		return payload, errors.Join(err, cacheErr)
+	}
+	if !ok {
+		return payload, err
+	}
+	return QuestionPayload{Mode: req.Mode, Content: content}, nil
+}
+
+// BatchEvaluateTavern is passed through to the wrapped backend.
+func (c *CachedProvider) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	return c.next.BatchEvaluateTavern(ctx, rubric, npcOpening, npcReplies, playerUtterances, langPref)
+}
+
+// TavernReply is passed through to the wrapped backend.
+func (c *CachedProvider) TavernReply(ctx context.Context, req TavernReplyRequest) (TavernReply, error) {
+	return RequestTavernReply(ctx, c.next, req)
+}
+
+func (c *CachedProvider) supportsLiveTavern() bool { return SupportsLiveTavern(c.next) }
+
+// Prefetch fetches one set for req unless an unserved set is already waiting.
+func (c *CachedProvider) Prefetch(ctx context.Context, req QuestionRequest) error {
+	key := cacheKey(req)
+	waiting, err := db.CountUnservedQuestionSets(ctx, key)
+	if err != nil {
+		return err
+	}
+	if waiting > 0 {
+		return nil
+	}
+	payload, err := c.fetchValid(ctx, req)
+	if err != nil {
+		return err
+	}
+	return db.SaveQuestionSet(ctx, key, payload.Content, false)
+}
+
+func (c *CachedProvider) repairsPayloads() {}
+
+// fetchValid fetches and repairs from the backend and only accepts payloads
+// that validate, so the cache never holds a set that would fail FetchAndValidate.
+func (c *CachedProvider) fetchValid(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	payload, err := fetchRepaired(ctx, c.next, req)
+	if err != nil {
+		return payload, err
+	}
+	payload.Mode = req.Mode
+	if err := ValidatePayload(payload); err != nil {
+		return payload, fmt.Errorf("validation failed for mode %s: %w", req.Mode, err)
+	}
+	return payload, nil
+}
+
+// Prefetch warms p's cache for the next session of each mode. Providers that
+// cannot prefetch are skipped.
+func Prefetch(ctx context.Context, p Provider, modes ...string) error {
+	pf, ok := p.(Prefetcher)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, mode := range modes {
+		if err := pf.Prefetch(ctx, NewQuestionRequest(ctx, mode)); err != nil {
+			errs = append(errs, fmt.Errorf("prefetch %s: %w", mode, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestCachedProvider_PrefetchAndReuse(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "cache.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	backend := &fakeProvider{content: []byte(fiveSpellingPrompts)}
+	cp := NewCachedProvider(backend)
+
+	if err := Prefetch(ctx, cp, ModeSpelling); err != nil {
+		t.Fatalf("Prefetch error: %v", err)
+	}
+	if err := Prefetch(ctx, cp, ModeSpelling); err != nil {
+		t.Fatalf("second Prefetch error: %v", err)
+	}
+	if len(backend.reqs) != 1 {
+		t.Fatalf("expected one backend call while a set is waiting, got %d", len(backend.reqs))
+	}
+
+	backend.err = errors.New("offline")
+	if _, err := FetchAndValidate(ctx, cp, ModeSpelling); err != nil {
+		t.Fatalf("expected prefetched set, got %v", err)
+	}
+	if len(backend.reqs) != 1 {
+		t.Fatalf("prefetched set should be served without a backend call, got %d calls", len(backend.reqs))
+	}
+
+	// Nothing unserved is left, so the failing backend triggers a replay.
+	if _, err := FetchAndValidate(ctx, cp, ModeSpelling); err != nil {
+		t.Fatalf("expected served set to be reused, got %v", err)
+	}
+
+	if _, err := FetchAndValidate(ctx, cp, ModeVocab); err == nil {
+		t.Fatalf("expected error for an uncached mode with a failing backend")
+	}
+}
+
+func TestCachedProvider_HardRequestsBypassCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "cache.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	backend := &fakeProvider{content: []byte(fiveSpellingPrompts)}
+	cp := NewCachedProvider(backend)
+
+	if err := Prefetch(ctx, cp, ModeSpelling); err != nil {
+		t.Fatalf("Prefetch error: %v", err)
+	}
+	req := NewQuestionRequest(ctx, ModeSpelling)
+	req.Hard = true
+	if _, err := FetchAndValidateRequest(ctx, cp, req); err != nil {
+		t.Fatalf("FetchAndValidateRequest error: %v", err)
+	}
+	if len(backend.reqs) != 2 || !backend.reqs[1].Hard {
+		t.Fatalf("expected the hard request to skip the prefetched set, got %+v", backend.reqs)
+	}
+	if n, _ := db.CountUnservedQuestionSets(ctx, cacheKey(req)); n != 1 {
+		t.Fatalf("expected the prefetched set to stay unserved, got %d", n)
+	}
+
+	prompt, err := buildQuestionPrompt(req)
+	if err != nil || !strings.Contains(prompt, "boss battle") {
+		t.Fatalf("expected a harder prompt, got %q (%v)", prompt, err)
+	}
+}
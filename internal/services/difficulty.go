@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+)
+
+// CEFRLevels lists the CEFR levels from easiest to hardest. Level tier n of
+// game.TierForLevel starts at CEFRLevels[n-1].
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+const (
+	// difficultyWindow is how many recent sessions are checked for accuracy.
+	difficultyWindow = 20
+	// difficultyMinSessions is how many sessions of a mode are needed before
+	// accuracy moves the target.
+	difficultyMinSessions = 3
+	// Accuracy at or above cefrStepUp raises the target one level; below
+	// cefrStepDown lowers it one level.
+	cefrStepUp   = 0.85
+	cefrStepDown = 0.55
+)
+
+// CEFRFor returns the CEFR target for a player at level whose recent accuracy
+// in a mode was accuracy over sessions sessions.
+func CEFRFor(level int, accuracy float64, sessions int) string {
+	tier, _ := game.TierForLevel(level)
+	i := tier - 1
+	if sessions >= difficultyMinSessions {
+		switch {
+		case accuracy >= cefrStepUp:
+			i++
+		case accuracy < cefrStepDown:
+			i--
+		}
+	}
+	i = min(max(i, 0), len(CEFRLevels)-1)
+	return CEFRLevels[i]
+}
+
+// cefrIndex returns the position of level in CEFRLevels, or -1.
+func cefrIndex(level string) int {
+	for i, l := range CEFRLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// targetCEFR derives the active profile's CEFR target for mode from its level
+// and its accuracy in that mode over the recent sessions. It returns "" when
+// there is no profile to go by.
+func targetCEFR(ctx context.Context, mode string) string {
+	playerID := db.CurrentProfileID()
+	if playerID == "" {
+		return ""
+	}
+	rec, err := db.LoadProfile(ctx, playerID)
+	if err != nil {
+		return ""
+	}
+	sessions, err := db.ListSessions(ctx, playerID, difficultyWindow)
+	if err != nil {
+		log.Printf("failed to load sessions for difficulty: %v", err)
+	}
+	accuracy, played := modeAccuracy(sessions, mode)
+	return CEFRFor(rec.Level, accuracy, played)
+}
+
+// modeAccuracy returns the share of correct answers in the sessions of mode
+// and how many sessions it covers. Each session counts the questions it
+// actually asked, so short review runs, faints and older session lengths
+// weigh correctly. Review runs are skipped since they only ask items the
+// player missed before, as are sessions without a question log, which
+// cannot be measured.
+func modeAccuracy(sessions []db.SessionRecord, mode string) (float64, int) {
+	played, correct, asked := 0, 0, 0
+	for _, s := range sessions {
+		if s.Mode != mode || s.Review || s.QuestionCount == 0 {
+			continue
+		}
+		played++
+		correct += s.CorrectCount
+		asked += s.QuestionCount
+	}
+	if asked == 0 {
+		return 0, 0
+	}
+	return float64(correct) / float64(asked), played
+}
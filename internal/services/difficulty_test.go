@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestCEFRFor(t *testing.T) {
+	cases := []struct {
+		level    int
+		accuracy float64
+		sessions int
+		want     string
+	}{
+		{1, 0, 0, "A1"},
+		{20, 0, 0, "A2"},
+		{150, 0.7, 10, "B2"},
+		{150, 0.9, 10, "C1"},
+		{150, 0.4, 10, "B1"},
+		{150, 0.9, 2, "B2"}, // too few sessions to move the target
+		{1, 0.2, 10, "A1"},
+		{500, 1, 10, "C2"},
+	}
+	for _, c := range cases {
+		if got := CEFRFor(c.level, c.accuracy, c.sessions); got != c.want {
+			t.Errorf("CEFRFor(%d, %.2f, %d) = %s, want %s", c.level, c.accuracy, c.sessions, got, c.want)
+		}
+	}
+}
+
+func TestNewQuestionRequest_TargetsProfileLevelAndAccuracy(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "difficulty.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	if req := NewQuestionRequest(ctx, ModeVocab); req.CEFR != "" {
+		t.Fatalf("expected no target without a profile, got %q", req.CEFR)
+	}
+
+	db.SetProfileID("player-1")
+	t.Cleanup(func() { db.SetProfileID("") })
+	if err := db.SaveProfile(ctx, db.ProfileRecord{ID: "player-1", Name: "Tester", Level: 60}); err != nil {
+		t.Fatalf("SaveProfile error: %v", err)
+	}
+	if req := NewQuestionRequest(ctx, ModeVocab); req.CEFR != "B1" {
+		t.Fatalf("expected level 60 to target B1, got %q", req.CEFR)
+	}
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		saveSessionWithItems(t, db.NewSessionRecord(ModeVocab, now, now), 5, 5)
+	}
+	if req := NewQuestionRequest(ctx, ModeVocab); req.CEFR != "B2" {
+		t.Fatalf("expected perfect vocab sessions to raise the target, got %q", req.CEFR)
+	}
+	if req := NewQuestionRequest(ctx, ModeGrammar); req.CEFR != "B1" {
+		t.Fatalf("expected grammar to keep the level target, got %q", req.CEFR)
+	}
+
+	// Short runs count the questions they asked, not the configured length.
+	for i := 0; i < 3; i++ {
+		saveSessionWithItems(t, db.NewSessionRecord(ModeGrammar, now, now), 2, 2)
+	}
+	if req := NewQuestionRequest(ctx, ModeGrammar); req.CEFR != "B2" {
+		t.Fatalf("expected perfect short grammar runs to raise the target, got %q", req.CEFR)
+	}
+
+	// Review runs only ask items missed before and leave accuracy alone.
+	for i := 0; i < 3; i++ {
+		rec := db.NewSessionRecord(ModeGrammar, now, now)
+		rec.Review = true
+		saveSessionWithItems(t, rec, 0, 2)
+	}
+	if req := NewQuestionRequest(ctx, ModeGrammar); req.CEFR != "B2" {
+		t.Fatalf("expected review runs not to lower the target, got %q", req.CEFR)
+	}
+
+	prompt, err := buildQuestionPrompt(QuestionRequest{Mode: ModeVocab, Count: 5, CEFR: "B2"})
+	if err != nil || !strings.Contains(prompt, "Target CEFR level: B2") {
+		t.Fatalf("expected the prompt to carry the target, got %q (%v)", prompt, err)
+	}
+}
+
+// saveSessionWithItems saves rec for player-1 with a question log of total
+// questions, the first correct of them answered correctly.
+func saveSessionWithItems(t *testing.T, rec db.SessionRecord, correct, total int) {
+	t.Helper()
+	ctx := context.Background()
+	if rec.PlayerID == "" {
+		rec.PlayerID = "player-1"
+	}
+	rec.CorrectCount = correct
+	if err := db.SaveSession(ctx, rec); err != nil {
+		t.Fatalf("SaveSession error: %v", err)
+	}
+	items := make([]db.SessionItem, total)
+	for i := range items {
+		items[i] = db.SessionItem{SessionID: rec.ID, PlayerID: rec.PlayerID, Mode: rec.Mode, Seq: i, Prompt: "q", Correct: i < correct}
+	}
+	if err := db.SaveSessionItems(ctx, items); err != nil {
+		t.Fatalf("SaveSessionItems error: %v", err)
+	}
+}
@@ -6,8 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strings" // Added strings import
-	"tui-english-quest/internal/config"
+	"strings"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
@@ -51,23 +50,73 @@ type QuestionPayload struct {
 	Content []byte
 }
 
-// FetchQuestions fetches questions for the given mode using the Gemini API.
-func FetchQuestions(ctx context.Context, mode string) (QuestionPayload, error) {
-	gc, err := NewGeminiClient(ctx)
+// FetchQuestions asks Gemini for a fresh question set matching req.
+func (gc *GeminiClient) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	return fetchQuestionsWith(ctx, gc, req)
+}
+
+// generateText sends a single prompt and concatenates the text parts of the first candidate.
+// With a schema the model is asked for JSON matching it.
+func (gc *GeminiClient) generateText(ctx context.Context, prompt string, schema *jsonSchema) (string, error) {
+	model := *gc.client // per-call copy so concurrent requests do not share the response config
+	if schema != nil {
+		model.ResponseMIMEType = "application/json"
+		model.ResponseSchema = genaiSchema(schema)
+	}
+	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
-		return QuestionPayload{}, fmt.Errorf("failed to create Gemini client: %w", err)
+		return "", fmt.Errorf("failed to generate content from Gemini API: %w", err)
 	}
-	// The genai.GenerativeModel does not have a Close() method, so we don't defer it here.
 
-	// Read user language preference and configured questions per session
-	langPref := "en"
-	cfg, _ := config.LoadConfig()
-	if cfg.LangPref == "ja" {
-		langPref = "ja"
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+		return "", errors.New("no content found in Gemini API response")
 	}
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
+
+	var b strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			b.WriteString(string(text))
+		}
+	}
+	return b.String(), nil
+}
+
+// genaiSchema converts s into Gemini's schema type.
+func genaiSchema(s *jsonSchema) *genai.Schema {
+	if s == nil {
+		return nil
+	}
+	gs := &genai.Schema{Enum: s.Enum, Items: genaiSchema(s.Items), Required: s.Required}
+	switch s.Type {
+	case "string":
+		gs.Type = genai.TypeString
+	case "integer":
+		gs.Type = genai.TypeInteger
+	case "number":
+		gs.Type = genai.TypeNumber
+	case "boolean":
+		gs.Type = genai.TypeBoolean
+	case "array":
+		gs.Type = genai.TypeArray
+	case "object":
+		gs.Type = genai.TypeObject
+		gs.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, p := range s.Properties {
+			gs.Properties[name] = genaiSchema(p)
+		}
+	}
+	if len(s.Enum) > 0 {
+		gs.Format = "enum"
+	}
+	return gs
+}
+
+// buildQuestionPrompt renders the generation prompt for the requested mode.
+func buildQuestionPrompt(req QuestionRequest) (string, error) {
+	mode := req.Mode
+	N := req.Count
+	if N <= 0 {
+		N = 5
 	}
 
 	prompt := fmt.Sprintf("Generate %d *new and diverse* %s questions in JSON format. The JSON should strictly adhere to the following structure for %s mode:\n\n", N, mode, mode)
@@ -76,14 +125,14 @@ func FetchQuestions(ctx context.Context, mode string) (QuestionPayload, error) {
 	switch mode {
 	case ModeGrammar, ModeTavern, ModeListening:
 		// Problem text (questions, NPC replies, listening prompts and options) must be English.
-		if langPref == "ja" {
+		if req.Lang == "ja" {
 			prompt = "Write all problem texts, prompts, NPC replies and options in English. Provide explanations/transcripts/evaluation reasons in Japanese. Return only JSON.\n\n" + prompt
 		} else {
 			prompt = "Write problem texts, prompts, NPC replies, options and explanations in English. Return only JSON.\n\n" + prompt
 		}
 	default:
 		// Vocab and Spelling follow user langPref for problem text.
-		if langPref == "ja" {
+		if req.Lang == "ja" {
 			prompt = "Please write the problem text in Japanese, but provide answers/options in English. Return only JSON.\n\n" + prompt
 		} else {
 			prompt = "Please write the problem text and answers in English. Return only JSON.\n\n" + prompt
@@ -158,45 +207,47 @@ Return ONLY the JSON object above (no explanation, no markdown, no surrounding t
   ]
 }`
 	default:
-		return QuestionPayload{}, fmt.Errorf("unknown mode: %s", mode)
+		return "", fmt.Errorf("unknown mode: %s", mode)
 	}
-
-	resp, err := gc.client.GenerateContent(ctx, genai.Text(prompt))
-	if err != nil {
-		return QuestionPayload{}, fmt.Errorf("failed to generate content from Gemini API: %w", err)
+	if req.CEFR != "" {
+		prompt += fmt.Sprintf("\n\nTarget CEFR level: %s. Choose words, grammar points, sentences and distractors that a %s learner is working on; avoid items that are much easier or much harder.\n", req.CEFR, req.CEFR)
 	}
-
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return QuestionPayload{}, errors.New("no content found in Gemini API response")
+	if req.Hard {
+		prompt += "\n\nThese questions are for a boss battle, so make them clearly harder than a normal set: aim one CEFR level above the target (C1-C2 when there is none), favour idioms and subtle grammar points, and use distractors that are plausible to a learner at the target level.\n"
+	}
+	if len(req.Exclude) > 0 {
+		prompt += "\n\nDo not repeat any of these items, which the set already contains:\n- " + strings.Join(req.Exclude, "\n- ") + "\n"
 	}
+	return prompt, nil
+}
 
-	var contentBytes []byte
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if text, ok := part.(genai.Text); ok {
-			contentBytes = append(contentBytes, []byte(text)...)
-		}
+// payloadFromText extracts the JSON payload from a model's raw text response.
+func payloadFromText(req QuestionRequest, text string) (QuestionPayload, error) {
+	// Structured output returns the object as is.
+	if obj, ok := directJSON(text); ok {
+		return QuestionPayload{Mode: req.Mode, Content: []byte(obj)}, nil
 	}
 
-	// The Gemini API might return markdown or surrounding text, so extract the first
-	// well-formed JSON object using a simple brace-matching parser that handles
-	// string literals and escapes.
-	jsonString := string(contentBytes)
-	extracted, ok := findJSONBlock(jsonString)
+	// Last resort for backends that ignore the schema: they might return
+	// markdown or surrounding text, so extract the first well-formed JSON
+	// object using a simple brace-matching parser that handles string
+	// literals and escapes.
+	extracted, ok := findJSONBlock(text)
 	if !ok {
-		return QuestionPayload{}, fmt.Errorf("could not extract JSON from Gemini API response: %s", jsonString)
+		return QuestionPayload{}, fmt.Errorf("could not extract JSON from model response: %s", text)
 	}
 
 	extractedJSON := []byte(extracted)
 
 	// If mode is tavern and the extracted JSON doesn't have the expected number
 	// of turns, scan all JSON blocks in the response and pick the first one that does.
-	if mode == ModeTavern {
+	if req.Mode == ModeTavern {
 		var te TavernEnvelope
-		if err := json.Unmarshal(extractedJSON, &te); err != nil || len(te.Turns) != N {
-			blocks := findJSONBlocks(jsonString)
+		if err := json.Unmarshal(extractedJSON, &te); err != nil || len(te.Turns) != req.Count {
+			blocks := findJSONBlocks(text)
 			for _, b := range blocks {
 				var cand TavernEnvelope
-				if err := json.Unmarshal([]byte(b), &cand); err == nil && len(cand.Turns) == N {
+				if err := json.Unmarshal([]byte(b), &cand); err == nil && len(cand.Turns) == req.Count {
 					extractedJSON = []byte(b)
 					break
 				}
@@ -204,7 +255,7 @@ Return ONLY the JSON object above (no explanation, no markdown, no surrounding t
 		}
 	}
 
-	return QuestionPayload{Mode: mode, Content: extractedJSON}, nil
+	return QuestionPayload{Mode: req.Mode, Content: extractedJSON}, nil
 }
 
 // findJSONBlocks returns all top-level JSON objects found in s, in order.
@@ -292,9 +343,18 @@ func findJSONBlock(s string) (string, bool) {
 	return "", false
 }
 
-// FetchAndValidate obtains a payload then validates schema/count.
-func FetchAndValidate(ctx context.Context, mode string) (QuestionPayload, error) {
-	payload, err := FetchQuestions(ctx, mode)
+// FetchAndValidate obtains a payload from p then validates schema/count.
+func FetchAndValidate(ctx context.Context, p QuestionProvider, mode string) (QuestionPayload, error) {
+	return FetchAndValidateRequest(ctx, p, NewQuestionRequest(ctx, mode))
+}
+
+// FetchAndValidateRequest is FetchAndValidate for a prepared request.
+func FetchAndValidateRequest(ctx context.Context, p QuestionProvider, req QuestionRequest) (QuestionPayload, error) {
+	mode := req.Mode
+	if p == nil {
+		return QuestionPayload{Mode: mode}, fmt.Errorf("failed to fetch questions for mode %s: %w", mode, ErrNoProvider)
+	}
+	payload, err := fetchRepaired(ctx, p, req)
 	if err != nil {
 		return payload, fmt.Errorf("failed to fetch questions for mode %s: %w", mode, err)
 	}
@@ -341,11 +401,7 @@ func validateVocab(raw []byte) error {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return fmt.Errorf("invalid vocab JSON: %w", err)
 	}
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
+	N := sessionQuestionCount()
 	if len(env.Questions) != N {
 		return fmt.Errorf("vocab questions must be %d, got %d", N, len(env.Questions))
 	}
@@ -374,11 +430,7 @@ func validateGrammar(raw []byte) error {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return fmt.Errorf("invalid grammar JSON: %w", err)
 	}
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
+	N := sessionQuestionCount()
 	if len(env.Traps) != N {
 		return fmt.Errorf("grammar traps must be %d, got %d", N, len(env.Traps))
 	}
@@ -406,11 +458,7 @@ func validateTavern(raw []byte) error {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return fmt.Errorf("invalid tavern JSON: %w", err)
 	}
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
+	N := sessionQuestionCount()
 	if len(env.Turns) != N {
 		return fmt.Errorf("tavern turns must be %d, got %d", N, len(env.Turns))
 	}
@@ -423,7 +471,7 @@ func validateTavern(raw []byte) error {
 // --- Batch evaluation types and functions ---
 
 type TavernEvaluation struct {
-	Outcome string `json:"outcome"` // "success"|"normal"|"fail"
+	Outcome string `json:"outcome" enum:"success,normal,fail"`
 	Reason  string `json:"reason"`
 }
 
@@ -434,47 +482,28 @@ type batchEvalEnvelope struct {
 // BatchEvaluateTavern evaluates N turns in one request.
 // langPref: "en" or "ja"
 func (gc *GeminiClient) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
-	if len(npcReplies) != N || len(playerUtterances) != N {
-		return nil, fmt.Errorf("expected %d npcReplies and %d playerUtterances", N, N)
-	}
-
-	prompt := buildBatchEvalPrompt(rubric, npcOpening, npcReplies, playerUtterances, langPref)
-
-	resp, err := gc.client.GenerateContent(ctx, genai.Text(prompt))
-	if err != nil {
-		return fallbackEvaluations(fmt.Errorf("gemini generate error: %w", err)), nil
-	}
-
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return fallbackEvaluations(fmt.Errorf("no content from gemini")), nil
-	}
+	return batchEvaluateWith(ctx, gc, rubric, npcOpening, npcReplies, playerUtterances, langPref)
+}
 
-	var contentBytes []byte
-	for _, part := range resp.Candidates[0].Content.Parts {
-		if text, ok := part.(genai.Text); ok {
-			contentBytes = append(contentBytes, []byte(text)...)
-		}
-	}
+// TavernReply answers the player's latest utterance in character and grades it.
+func (gc *GeminiClient) TavernReply(ctx context.Context, req TavernReplyRequest) (TavernReply, error) {
+	return tavernReplyWith(ctx, gc, req)
+}
 
-	jsonStr := string(contentBytes)
-	extracted, ok := findJSONBlock(jsonStr)
+// parseBatchEvaluations decodes an evaluation response, falling back to "normal" outcomes when it is unusable.
+func parseBatchEvaluations(text string, expected int) []TavernEvaluation {
+	extracted, ok := extractJSON(text)
 	if !ok {
-		return fallbackEvaluations(fmt.Errorf("could not extract JSON from response: %s", jsonStr)), nil
+		return fallbackEvaluations(expected, fmt.Errorf("could not extract JSON from response: %s", text))
 	}
 
 	var env batchEvalEnvelope
 	if err := json.Unmarshal([]byte(extracted), &env); err != nil {
-		return fallbackEvaluations(fmt.Errorf("invalid JSON: %w", err)), nil
+		return fallbackEvaluations(expected, fmt.Errorf("invalid JSON: %w", err))
 	}
 
-	expected := len(npcReplies)
 	if len(env.Evaluations) != expected {
-		return fallbackEvaluations(fmt.Errorf("evaluations length != %d: %d", expected, len(env.Evaluations))), nil
+		return fallbackEvaluations(expected, fmt.Errorf("evaluations length != %d: %d", expected, len(env.Evaluations)))
 	}
 
 	for i := range env.Evaluations {
@@ -482,16 +511,16 @@ func (gc *GeminiClient) BatchEvaluateTavern(ctx context.Context, rubric []string
 		case "success", "normal", "fail":
 			// ok
 		default:
-			return fallbackEvaluations(fmt.Errorf("invalid outcome: %s", env.Evaluations[i].Outcome)), nil
+			return fallbackEvaluations(expected, fmt.Errorf("invalid outcome: %s", env.Evaluations[i].Outcome))
 		}
 	}
 
-	return env.Evaluations, nil
+	return env.Evaluations
 }
 
-func fallbackEvaluations(err error) []TavernEvaluation {
+func fallbackEvaluations(n int, err error) []TavernEvaluation {
 	fmt.Fprintf(os.Stderr, "BatchEvaluateTavern fallback: %v\n", err)
-	res := make([]TavernEvaluation, 5)
+	res := make([]TavernEvaluation, n)
 	for i := range res {
 		res[i] = TavernEvaluation{
 			Outcome: "normal",
@@ -553,11 +582,7 @@ func validateSpelling(raw []byte) error {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return fmt.Errorf("invalid spelling JSON: %w", err)
 	}
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
+	N := sessionQuestionCount()
 	if len(env.Prompts) != N {
 		return fmt.Errorf("spelling prompts must be %d, got %d", N, len(env.Prompts))
 	}
@@ -580,11 +605,7 @@ func validateListening(raw []byte) error {
 	if err := json.Unmarshal(raw, &env); err != nil {
 		return fmt.Errorf("invalid listening JSON: %w", err)
 	}
-	cfg, _ := config.LoadConfig()
-	N := 5
-	if cfg.QuestionsPerSession > 0 {
-		N = cfg.QuestionsPerSession
-	}
+	N := sessionQuestionCount()
 	if len(env.Audio) != N {
 		return fmt.Errorf("listening audio items must be %d, got %d", N, len(env.Audio))
 	}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"tui-english-quest/internal/config"
+)
+
+// OpenAISettings configures an OpenAI-compatible chat completions backend.
+type OpenAISettings struct {
+	BaseURL string
+	Model   string
+	APIKey  string
+}
+
+// OpenAIClient talks to an OpenAI-compatible /v1/chat/completions endpoint
+// such as llama.cpp's server or vLLM.
+type OpenAIClient struct {
+	endpoint   string
+	model      string
+	apiKey     string
+	httpClient *http.Client
+
+	// noSchema is set once the server rejects response_format, so later
+	// requests go out as plain text and rely on the JSON scanner.
+	noSchema atomic.Bool
+}
+
+// NewOpenAIClient initializes a client for the given server settings.
+func NewOpenAIClient(s OpenAISettings) (*OpenAIClient, error) {
+	base := strings.TrimRight(strings.TrimSpace(s.BaseURL), "/")
+	if base == "" {
+		return nil, errors.New("OpenAI-compatible base URL not set (OPENAI_BASE_URL or openai_base_url)")
+	}
+	endpoint := base + "/v1/chat/completions"
+	if strings.HasSuffix(base, "/v1") {
+		endpoint = base + "/chat/completions"
+	}
+	return &OpenAIClient{
+		endpoint:   endpoint,
+		model:      s.Model,
+		apiKey:     s.APIKey,
+		httpClient: &http.Client{Timeout: 120 * time.Second},
+	}, nil
+}
+
+// openAISettings merges config values with OPENAI_* environment overrides.
+func openAISettings(cfg config.Config) OpenAISettings {
+	s := OpenAISettings{BaseURL: cfg.OpenAIBaseURL, Model: cfg.OpenAIModel, APIKey: cfg.OpenAIApiKey}
+	if v := os.Getenv("OPENAI_BASE_URL"); v != "" {
+		s.BaseURL = v
+	}
+	if v := os.Getenv("OPENAI_MODEL"); v != "" {
+		s.Model = v
+	}
+	if v := os.Getenv("OPENAI_API_KEY"); v != "" {
+		s.APIKey = v
+	}
+	return s
+}
+
+// FetchQuestions asks the chat completions server for a fresh question set matching req.
+func (oc *OpenAIClient) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	return fetchQuestionsWith(ctx, oc, req)
+}
+
+// BatchEvaluateTavern evaluates N turns in one request.
+func (oc *OpenAIClient) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	return batchEvaluateWith(ctx, oc, rubric, npcOpening, npcReplies, playerUtterances, langPref)
+}
+
+// TavernReply answers the player's latest utterance in character and grades it.
+func (oc *OpenAIClient) TavernReply(ctx context.Context, req TavernReplyRequest) (TavernReply, error) {
+	return tavernReplyWith(ctx, oc, req)
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string          `json:"model,omitempty"`
+	Messages       []chatMessage   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+type responseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema *namedJSONSchema `json:"json_schema,omitempty"`
+}
+
+type namedJSONSchema struct {
+	Name   string      `json:"name"`
+	Strict bool        `json:"strict"`
+	Schema *jsonSchema `json:"schema"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// generateText sends prompt as a single user message and returns the first choice.
+// A schema is sent as a strict json_schema response format.
+func (oc *OpenAIClient) generateText(ctx context.Context, prompt string, schema *jsonSchema) (string, error) {
+	req := chatRequest{
+		Model:    oc.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	}
+	if schema != nil && !oc.noSchema.Load() {
+		req.ResponseFormat = &responseFormat{
+			Type:       "json_schema",
+			JSONSchema: &namedJSONSchema{Name: schema.name, Strict: true, Schema: schema},
+		}
+	}
+	text, status, err := oc.complete(ctx, req)
+	if err != nil && status == http.StatusBadRequest && req.ResponseFormat != nil {
+		oc.noSchema.Store(true)
+		req.ResponseFormat = nil
+		text, _, err = oc.complete(ctx, req)
+	}
+	return text, err
+}
+
+// complete posts req and returns the first choice along with the HTTP status.
+func (oc *OpenAIClient) complete(ctx context.Context, req chatRequest) (string, int, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to encode chat request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, oc.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to build chat request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if oc.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+oc.apiKey)
+	}
+
+	resp, err := oc.httpClient.Do(httpReq)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to call chat completions endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", resp.StatusCode, fmt.Errorf("failed to read chat response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode, fmt.Errorf("chat completions returned %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	var cr chatResponse
+	if err := json.Unmarshal(raw, &cr); err != nil {
+		return "", resp.StatusCode, fmt.Errorf("invalid chat response JSON: %w", err)
+	}
+	if cr.Error != nil {
+		return "", resp.StatusCode, fmt.Errorf("chat completions error: %s", cr.Error.Message)
+	}
+	if len(cr.Choices) == 0 || cr.Choices[0].Message.Content == "" {
+		return "", resp.StatusCode, errors.New("no content found in chat completions response")
+	}
+	return cr.Choices[0].Message.Content, resp.StatusCode, nil
+}
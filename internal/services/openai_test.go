@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newChatServer(t *testing.T, content string, gotReq *chatRequest) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if gotReq != nil {
+			_ = json.NewDecoder(r.Body).Decode(gotReq)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": content}}},
+		})
+	}))
+}
+
+func TestOpenAIClient_FetchQuestions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var req chatRequest
+	srv := newChatServer(t, "Sure! Here you go:\n```json\n"+fiveSpellingPrompts+"\n```", &req)
+	defer srv.Close()
+
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: srv.URL + "/v1/", Model: "local-model", APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	payload, err := FetchAndValidate(context.Background(), oc, ModeSpelling)
+	if err != nil {
+		t.Fatalf("FetchAndValidate error: %v", err)
+	}
+	var env SpellingEnvelope
+	if err := json.Unmarshal(payload.Content, &env); err != nil || len(env.Prompts) != 5 {
+		t.Fatalf("expected 5 spelling prompts, got %d (%v)", len(env.Prompts), err)
+	}
+	if req.Model != "local-model" || len(req.Messages) != 1 || req.Messages[0].Role != "user" {
+		t.Fatalf("unexpected chat request: %+v", req)
+	}
+}
+
+func TestOpenAIClient_BatchEvaluateTavern(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	srv := newChatServer(t, `{"evaluations":[
+		{"outcome":"success","reason":"a"},{"outcome":"normal","reason":"b"},{"outcome":"fail","reason":"c"},
+		{"outcome":"success","reason":"d"},{"outcome":"normal","reason":"e"}]}`, nil)
+	defer srv.Close()
+
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: srv.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	turns := make([]TavernTurn, 5)
+	utterances := []string{"hi", "hello", "ok", "thanks", "bye"}
+	evals, err := oc.BatchEvaluateTavern(context.Background(), []string{"s", "n", "f"}, "Welcome!", turns, utterances, "en")
+	if err != nil {
+		t.Fatalf("BatchEvaluateTavern error: %v", err)
+	}
+	if len(evals) != 5 || evals[2].Outcome != "fail" {
+		t.Fatalf("unexpected evaluations: %+v", evals)
+	}
+}
+
+func TestNewOpenAIClient_RequiresBaseURL(t *testing.T) {
+	if _, err := NewOpenAIClient(OpenAISettings{}); err == nil {
+		t.Fatalf("expected error for empty base URL")
+	}
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed packs/*.json
+var builtinPacks embed.FS
+
+// packFile is the union of every contract envelope in gemini-contracts.md.
+// A single file may carry items for any number of modes; a tavern scene can be
+// written either at the top level (tavern envelope keys) or under "scenes".
+// CEFR optionally tags every item in the file with its level.
+type packFile struct {
+	CEFR      string           `json:"cefr"`
+	Questions []VocabQuestion  `json:"questions"`
+	Traps     []GrammarTrap    `json:"traps"`
+	Prompts   []SpellingPrompt `json:"prompts"`
+	Audio     []ListeningItem  `json:"audio"`
+	Scenes    []TavernEnvelope `json:"scenes"`
+	TavernEnvelope
+}
+
+// leveled is a pack item with the CEFR level of its pack ("" when untagged).
+type leveled[T any] struct {
+	item T
+	cefr string
+}
+
+func tagLevel[T any](items []T, cefr string) []leveled[T] {
+	out := make([]leveled[T], len(items))
+	for i, it := range items {
+		out[i] = leveled[T]{item: it, cefr: cefr}
+	}
+	return out
+}
+
+// questionBank holds every pack item grouped by mode.
+type questionBank struct {
+	vocab     []leveled[VocabQuestion]
+	grammar   []leveled[GrammarTrap]
+	spelling  []leveled[SpellingPrompt]
+	listening []leveled[ListeningItem]
+	tavern    []leveled[TavernEnvelope]
+}
+
+func (b *questionBank) add(p packFile) {
+	b.vocab = append(b.vocab, tagLevel(p.Questions, p.CEFR)...)
+	b.grammar = append(b.grammar, tagLevel(p.Traps, p.CEFR)...)
+	b.spelling = append(b.spelling, tagLevel(p.Prompts, p.CEFR)...)
+	b.listening = append(b.listening, tagLevel(p.Audio, p.CEFR)...)
+	b.tavern = append(b.tavern, tagLevel(p.Scenes, p.CEFR)...)
+	if len(p.TavernEnvelope.Turns) > 0 {
+		b.tavern = append(b.tavern, leveled[TavernEnvelope]{item: p.TavernEnvelope, cefr: p.CEFR})
+	}
+}
+
+// PackProvider serves questions drawn at random from local pack files, so
+// sessions work without network access. The built-in starter pack is always
+// included; files in dir (.json, .yaml, .yml) extend it.
+type PackProvider struct {
+	dir string
+}
+
+// NewPackProvider returns a provider reading packs from dir. An empty or
+// missing dir leaves only the built-in starter pack.
+func NewPackProvider(dir string) *PackProvider {
+	return &PackProvider{dir: dir}
+}
+
+// FetchQuestions draws req.Count random items for req.Mode from the packs,
+// preferring items tagged at or near req.CEFR.
+func (pp *PackProvider) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	bank, err := pp.load()
+	if err != nil {
+		return QuestionPayload{}, err
+	}
+	N := req.Count
+	if N <= 0 {
+		N = 5
+	}
+
+	var env any
+	switch req.Mode {
+	case ModeVocab:
+		items, err := drawItems(forLevel(bank.vocab, req.CEFR, N), N, req.Mode)
+		if err != nil {
+			return QuestionPayload{}, err
+		}
+		env = VocabEnvelope{Questions: items}
+	case ModeGrammar:
+		items, err := drawItems(forLevel(bank.grammar, req.CEFR, N), N, req.Mode)
+		if err != nil {
+			return QuestionPayload{}, err
+		}
+		env = GrammarEnvelope{Traps: items}
+	case ModeSpelling:
+		items, err := drawItems(forLevel(bank.spelling, req.CEFR, N), N, req.Mode)
+		if err != nil {
+			return QuestionPayload{}, err
+		}
+		env = SpellingEnvelope{Prompts: items}
+	case ModeListening:
+		items, err := drawItems(forLevel(bank.listening, req.CEFR, N), N, req.Mode)
+		if err != nil {
+			return QuestionPayload{}, err
+		}
+		env = ListeningEnvelope{Audio: items}
+	case ModeTavern:
+		scene, err := drawScene(forLevel(withTurns(bank.tavern, N), req.CEFR, 1), N)
+		if err != nil {
+			return QuestionPayload{}, err
+		}
+		env = scene
+	default:
+		return QuestionPayload{}, fmt.Errorf("unknown mode: %s", req.Mode)
+	}
+
+	content, err := json.Marshal(env)
+	if err != nil {
+		return QuestionPayload{}, fmt.Errorf("failed to encode pack questions: %w", err)
+	}
+	return QuestionPayload{Mode: req.Mode, Content: content}, nil
+}
+
+// BatchEvaluateTavern grades utterances with simple offline heuristics.
+func (pp *PackProvider) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	evals := make([]TavernEvaluation, len(playerUtterances))
+	for i, u := range playerUtterances {
+		evals[i] = heuristicEvaluation(u, langPref)
+	}
+	return evals, nil
+}
+
+// load reads the built-in pack plus every pack file under pp.dir.
+func (pp *PackProvider) load() (questionBank, error) {
+	var bank questionBank
+	if err := loadPackFS(builtinPacks, ".", &bank); err != nil {
+		return bank, err
+	}
+	if pp.dir == "" {
+		return bank, nil
+	}
+	if _, err := os.Stat(pp.dir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return bank, nil
+		}
+		return bank, fmt.Errorf("failed to read pack dir: %w", err)
+	}
+	if err := loadPackFS(os.DirFS(pp.dir), ".", &bank); err != nil {
+		return bank, err
+	}
+	return bank, nil
+}
+
+func loadPackFS(fsys fs.FS, root string, bank *questionBank) error {
+	return fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		raw, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read pack %s: %w", path, err)
+		}
+		p, err := parsePack(raw, ext)
+		if err != nil {
+			return fmt.Errorf("invalid pack %s: %w", path, err)
+		}
+		bank.add(p)
+		return nil
+	})
+}
+
+// parsePack decodes a pack file. YAML is converted to JSON first so that the
+// contract types only need their json tags.
+func parsePack(raw []byte, ext string) (packFile, error) {
+	var p packFile
+	if ext == ".yaml" || ext == ".yml" {
+		var doc any
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			return p, err
+		}
+		converted, err := json.Marshal(doc)
+		if err != nil {
+			return p, err
+		}
+		raw = converted
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// forLevel returns the pool items closest to the CEFR target: untagged items
+// plus tagged ones within the smallest distance of target that yields at
+// least n items. Without a target every item qualifies.
+func forLevel[T any](pool []leveled[T], target string, n int) []T {
+	t := cefrIndex(target)
+	for dist := 0; ; dist++ {
+		var out []T
+		for _, p := range pool {
+			i := cefrIndex(p.cefr)
+			if t < 0 || i < 0 || abs(i-t) <= dist {
+				out = append(out, p.item)
+			}
+		}
+		if len(out) >= n || dist >= len(CEFRLevels) {
+			return out
+		}
+	}
+}
+
+// withTurns returns the tavern scenes with at least n turns, so that picking
+// by level never settles on scenes too short to play.
+func withTurns(scenes []leveled[TavernEnvelope], n int) []leveled[TavernEnvelope] {
+	var out []leveled[TavernEnvelope]
+	for _, s := range scenes {
+		if len(s.item.Turns) >= n {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// drawItems returns n distinct random items from pool.
+func drawItems[T any](pool []T, n int, mode string) ([]T, error) {
+	if len(pool) < n {
+		return nil, fmt.Errorf("question packs have %d %s items, need %d", len(pool), mode, n)
+	}
+	out := make([]T, 0, n)
+	for _, i := range rand.Perm(len(pool))[:n] {
+		out = append(out, pool[i])
+	}
+	return out, nil
+}
+
+// drawScene picks a random tavern scene with at least n turns and trims it to n.
+func drawScene(scenes []TavernEnvelope, n int) (TavernEnvelope, error) {
+	var fit []TavernEnvelope
+	for _, s := range scenes {
+		if len(s.Turns) >= n {
+			fit = append(fit, s)
+		}
+	}
+	if len(fit) == 0 {
+		return TavernEnvelope{}, fmt.Errorf("question packs have no tavern scene with %d turns", n)
+	}
+	scene := fit[rand.Intn(len(fit))]
+	scene.Turns = append([]TavernTurn(nil), scene.Turns[:n]...)
+	if len(scene.EvaluationRubric) < 3 {
+		scene.EvaluationRubric = []string{
+			"Success: fluent, relevant, task completed",
+			"Normal: understandable, minor issues",
+			"Fail: unclear or off-topic",
+		}
+	}
+	return scene, nil
+}
+
+// heuristicEvaluation grades a single utterance without a language model.
+func heuristicEvaluation(utterance, langPref string) TavernEvaluation {
+	reason := func(en, ja string) string {
+		if langPref == "ja" {
+			return ja
+		}
+		return en
+	}
+	text := strings.TrimSpace(utterance)
+	if text == "" {
+		return TavernEvaluation{Outcome: "fail", Reason: reason("No reply given.", "返答がありません。")}
+	}
+	letters, latin := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if r < unicode.MaxASCII {
+				latin++
+			}
+		}
+	}
+	if letters == 0 || latin*2 < letters {
+		return TavernEvaluation{Outcome: "fail", Reason: reason("Please reply in English.", "英語で返答しましょう。")}
+	}
+	if len(strings.Fields(text)) < 3 {
+		return TavernEvaluation{Outcome: "normal", Reason: reason("Understandable, but try a full sentence.", "通じますが、文章で答えてみましょう。")}
+	}
+	return TavernEvaluation{Outcome: "success", Reason: reason("Clear, complete English reply.", "明確な英語の返答です。")}
+}
+
+// fallbackProvider tries primary first and serves from fallback when it fails,
+// e.g. an online backend without network access.
+type fallbackProvider struct {
+	primary  Provider
+	fallback Provider
+}
+
+func (f fallbackProvider) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	payload, err := fetchRepaired(ctx, f.primary, req)
+	if err == nil {
+		return payload, nil
+	}
+	fb, fbErr := f.fallback.FetchQuestions(ctx, req)
+	if fbErr != nil {
+		return payload, errors.Join(err, fbErr)
+	}
+	return fb, nil
+}
+
+func (f fallbackProvider) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	evals, err := f.primary.BatchEvaluateTavern(ctx, rubric, npcOpening, npcReplies, playerUtterances, langPref)
+	if err != nil {
+		return f.fallback.BatchEvaluateTavern(ctx, rubric, npcOpening, npcReplies, playerUtterances, langPref)
+	}
+	return evals, nil
+}
+
+func (f fallbackProvider) repairsPayloads() {}
+
+// TavernReply only uses the primary: the packs have no live NPC, so a failure
+// is reported and the caller continues with the scripted turns.
+func (f fallbackProvider) TavernReply(ctx context.Context, req TavernReplyRequest) (TavernReply, error) {
+	return RequestTavernReply(ctx, f.primary, req)
+}
+
+func (f fallbackProvider) supportsLiveTavern() bool { return SupportsLiveTavern(f.primary) }
+
+// Prefetch warms the primary backend; the packs need no warming.
+func (f fallbackProvider) Prefetch(ctx context.Context, req QuestionRequest) error {
+	if pf, ok := f.primary.(Prefetcher); ok {
+		return pf.Prefetch(ctx, req)
+	}
+	return nil
+}
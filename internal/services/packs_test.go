@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPackProvider_AllModesValidate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	pp := NewPackProvider("")
+	for _, mode := range []string{ModeVocab, ModeGrammar, ModeSpelling, ModeListening, ModeTavern} {
+		if _, err := FetchAndValidate(context.Background(), pp, mode); err != nil {
+			t.Errorf("%s: %v", mode, err)
+		}
+	}
+}
+
+func TestPackProvider_LoadsYAMLPacks(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	var b strings.Builder
+	b.WriteString("prompts:\n")
+	for i := 0; i < 20; i++ {
+		b.WriteString("  - ja_hint: カスタム\n    correct_spelling: zzcustom\n    explanation: \"\"\n")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "custom.yaml"), []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bank, err := NewPackProvider(dir).load()
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	custom := 0
+	for _, p := range bank.spelling {
+		if p.item.CorrectSpelling == "zzcustom" {
+			custom++
+		}
+	}
+	if custom != 20 {
+		t.Fatalf("expected 20 YAML prompts, got %d", custom)
+	}
+}
+
+func TestPackProvider_TooFewItems(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	_, err := NewPackProvider("").FetchQuestions(context.Background(), QuestionRequest{Mode: ModeVocab, Count: 1000})
+	if err == nil {
+		t.Fatalf("expected error when packs have too few items")
+	}
+}
+
+func TestHeuristicEvaluation(t *testing.T) {
+	cases := map[string]string{
+		"":                            "fail",
+		"こんにちは":                       "fail",
+		"Hi there":                    "normal",
+		"I would like a room, please": "success",
+	}
+	for in, want := range cases {
+		if got := heuristicEvaluation(in, "en").Outcome; got != want {
+			t.Errorf("heuristicEvaluation(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestFallbackProvider_UsesPacksOnError(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp := fallbackProvider{primary: &fakeProvider{err: ErrNoProvider}, fallback: NewPackProvider("")}
+	payload, err := FetchAndValidate(context.Background(), fp, ModeGrammar)
+	if err != nil {
+		t.Fatalf("FetchAndValidate error: %v", err)
+	}
+	var env GrammarEnvelope
+	if err := json.Unmarshal(payload.Content, &env); err != nil || len(env.Traps) != 5 {
+		t.Fatalf("expected 5 grammar traps, got %d (%v)", len(env.Traps), err)
+	}
+}
+
+func TestPackProvider_PrefersTargetLevel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	write := func(name, cefr, answer string) {
+		var b strings.Builder
+		b.WriteString("cefr: " + cefr + "\nprompts:\n")
+		for i := 0; i < 5; i++ {
+			b.WriteString("  - ja_hint: テスト\n    correct_spelling: " + answer + "\n    explanation: \"\"\n")
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("easy.yaml", "A1", "zzeasy")
+	write("hard.yaml", "C2", "zzhard")
+	bank, err := NewPackProvider(dir).load()
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+
+	for _, p := range forLevel(bank.spelling, "C1", 5) {
+		if p.CorrectSpelling == "zzeasy" {
+			t.Fatalf("expected C1 to skip A1 items while C2 items suffice")
+		}
+	}
+	if got := len(forLevel(bank.spelling, "", 5)); got != len(bank.spelling) {
+		t.Fatalf("expected no target to keep all %d items, got %d", len(bank.spelling), got)
+	}
+	// Widening the level range never drops below the requested count.
+	if got := len(forLevel(bank.spelling, "C1", 1000)); got != len(bank.spelling) {
+		t.Fatalf("expected every item when the target range runs short, got %d", got)
+	}
+}
+
+func TestPackProvider_TavernSkipsShortScenesAtTargetLevel(t *testing.T) {
+	scene := func(opening string, turns int) TavernEnvelope {
+		return TavernEnvelope{NPCOpening: opening, Turns: make([]TavernTurn, turns)}
+	}
+	pool := []leveled[TavernEnvelope]{
+		{item: scene("short", 2), cefr: "C1"},
+		{item: scene("long", 5), cefr: "A1"},
+	}
+	got := forLevel(withTurns(pool, 5), "C1", 1)
+	if len(got) != 1 || got[0].NPCOpening != "long" {
+		t.Fatalf("expected the long A1 scene, got %+v", got)
+	}
+	if _, err := drawScene(got, 5); err != nil {
+		t.Fatalf("drawScene error: %v", err)
+	}
+}
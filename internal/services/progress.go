@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+// ProgressWindows are the selectable chart windows in days.
+var ProgressWindows = []int{7, 30, 90}
+
+// progressMaxPoints caps the points of a chart series; longer windows group
+// several days per point.
+const progressMaxPoints = 30
+
+// ProgressReport holds the chart series for the sessions of one window.
+// Series indexed by point start at Start and cover BucketDays days each.
+type ProgressReport struct {
+	Window     int // days
+	Start      time.Time
+	BucketDays int
+	Exp        []int                // EXP gained per point
+	Accuracy   map[string][]float64 // per mode and point; -1 when the mode was not played
+	Modes      []string             // modes in Accuracy, in play order
+	Weeks      []time.Time          // start of each week of the window
+	Faints     []int                // faints per week
+	Sessions   int
+	TotalExp   int
+}
+
+// BuildProgress aggregates the player's sessions of the last window days up
+// to now into chart series. Accuracy is measured like the difficulty target
+// (see modeAccuracy): against the questions each session asked, skipping
+// review runs and sessions without a question log. Boss battles count
+// towards EXP and faints but not accuracy, since they end as soon as the boss
+// falls.
+func BuildProgress(ctx context.Context, playerID string, window int, now time.Time) (ProgressReport, error) {
+	if window <= 0 {
+		window = ProgressWindows[0]
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, 0, 1-window)
+	bucket := (window + progressMaxPoints - 1) / progressMaxPoints
+	points := (window + bucket - 1) / bucket
+	weeks := (window + 6) / 7
+
+	r := ProgressReport{
+		Window:     window,
+		Start:      start,
+		BucketDays: bucket,
+		Exp:        make([]int, points),
+		Accuracy:   map[string][]float64{},
+		Faints:     make([]int, weeks),
+	}
+	for w := range weeks {
+		r.Weeks = append(r.Weeks, start.AddDate(0, 0, 7*w))
+	}
+
+	sessions, err := db.ListSessionsPage(ctx, playerID, db.SessionFilter{Since: start}, -1, 0)
+	if err != nil {
+		return r, err
+	}
+	correct := map[string][]int{}
+	asked := map[string][]int{}
+	firstSeen := map[string]time.Time{}
+	for _, s := range sessions {
+		day := daysBetween(start, s.EndedAt.In(now.Location()))
+		if day < 0 || day >= window {
+			continue
+		}
+		p := day / bucket
+		r.Sessions++
+		r.TotalExp += s.ExpGained
+		r.Exp[p] += s.ExpGained
+		if s.Fainted {
+			r.Faints[day/7]++
+		}
+		if s.Mode == "boss" || s.Review || s.QuestionCount == 0 {
+			continue
+		}
+		if correct[s.Mode] == nil {
+			correct[s.Mode] = make([]int, points)
+			asked[s.Mode] = make([]int, points)
+		}
+		correct[s.Mode][p] += s.CorrectCount
+		asked[s.Mode][p] += s.QuestionCount
+		if t, ok := firstSeen[s.Mode]; !ok || s.EndedAt.Before(t) {
+			firstSeen[s.Mode] = s.EndedAt
+		}
+	}
+
+	for mode := range correct {
+		series := make([]float64, points)
+		for p := range series {
+			series[p] = -1
+			if n := asked[mode][p]; n > 0 {
+				series[p] = float64(correct[mode][p]) / float64(n)
+			}
+		}
+		r.Accuracy[mode] = series
+		r.Modes = append(r.Modes, mode)
+	}
+	sort.Slice(r.Modes, func(i, j int) bool {
+		return firstSeen[r.Modes[i]].Before(firstSeen[r.Modes[j]])
+	})
+	return r, nil
+}
+
+// daysBetween counts calendar days from the midnight start to t. Rounding
+// absorbs days lengthened or shortened by daylight saving.
+func daysBetween(start, t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, start.Location())
+	return int(math.Round(day.Sub(start).Hours() / 24))
+}
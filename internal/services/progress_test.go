@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tui-english-quest/internal/db"
+)
+
+func TestBuildProgress_BucketsExpAccuracyAndFaints(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := db.InitDB(filepath.Join(t.TempDir(), "progress.sqlite")); err != nil {
+		t.Fatalf("InitDB error: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Date(2026, 6, 30, 18, 0, 0, 0, time.UTC)
+	save := func(id, mode string, daysAgo, correct, asked, exp int, fainted bool) {
+		ended := now.AddDate(0, 0, -daysAgo)
+		rec := db.SessionRecord{ID: id, PlayerID: "p1", Mode: mode, StartedAt: ended, EndedAt: ended, ExpGained: exp, Fainted: fainted}
+		saveSessionWithItems(t, rec, correct, asked)
+	}
+	save("a", ModeVocab, 0, 5, 5, 30, false)
+	save("b", ModeVocab, 0, 3, 5, 10, false)
+	save("c", ModeGrammar, 2, 1, 2, 5, true) // fainted after two questions
+	save("d", "boss", 6, 4, 5, 200, true)
+	save("e", ModeVocab, 10, 5, 5, 99, false) // outside the 7-day window
+	save("f", ModeSpelling, 80, 2, 5, 7, true)
+	save("g", ModeListening, 1, 0, 0, 3, false) // no question log to measure
+
+	week, err := BuildProgress(ctx, "p1", 7, now)
+	if err != nil {
+		t.Fatalf("BuildProgress error: %v", err)
+	}
+	if week.BucketDays != 1 || len(week.Exp) != 7 || week.Sessions != 5 || week.TotalExp != 248 {
+		t.Fatalf("unexpected 7-day report %+v", week)
+	}
+	if week.Exp[6] != 40 || week.Exp[5] != 3 || week.Exp[4] != 5 || week.Exp[0] != 200 {
+		t.Fatalf("unexpected daily EXP %v", week.Exp)
+	}
+	if got := week.Accuracy[ModeVocab][6]; got != 0.8 {
+		t.Fatalf("expected 8/10 vocab accuracy today, got %v", got)
+	}
+	if week.Accuracy[ModeVocab][5] != -1 {
+		t.Fatalf("expected no vocab data yesterday, got %v", week.Accuracy[ModeVocab][5])
+	}
+	if got := week.Accuracy[ModeGrammar][4]; got != 0.5 {
+		t.Fatalf("expected 1/2 grammar accuracy for the fainted run, got %v", got)
+	}
+	if _, ok := week.Accuracy[ModeListening]; ok {
+		t.Fatal("sessions without a question log should not count towards accuracy")
+	}
+	if _, ok := week.Accuracy["boss"]; ok {
+		t.Fatal("boss battles should not count towards accuracy")
+	}
+	if len(week.Faints) != 1 || week.Faints[0] != 2 {
+		t.Fatalf("expected 2 faints in one week, got %v", week.Faints)
+	}
+
+	quarter, err := BuildProgress(ctx, "p1", 90, now)
+	if err != nil {
+		t.Fatalf("BuildProgress error: %v", err)
+	}
+	if quarter.BucketDays != 3 || len(quarter.Exp) != 30 || len(quarter.Weeks) != 13 || quarter.Sessions != 7 {
+		t.Fatalf("unexpected 90-day report: bucket %d, %d points, %d weeks, %d sessions",
+			quarter.BucketDays, len(quarter.Exp), len(quarter.Weeks), quarter.Sessions)
+	}
+	if quarter.Modes[0] != ModeSpelling || quarter.Faints[1] != 1 {
+		t.Fatalf("expected spelling first and its faint in week 2, got %v %v", quarter.Modes, quarter.Faints)
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"tui-english-quest/internal/config"
+)
+
+// Backend names accepted in config.Config.Backend and QUESTION_BACKEND.
+const (
+	BackendGemini  = "gemini"
+	BackendOpenAI  = "openai"
+	BackendOffline = "offline" // local question packs only
+)
+
+// ErrNoProvider is returned when a mode needs questions but no backend is configured.
+var ErrNoProvider = errors.New("no question provider configured")
+
+// QuestionRequest describes the question set a provider should produce.
+type QuestionRequest struct {
+	Mode    string
+	Count   int
+	Lang    string   // "en"/"ja"
+	Exclude []string // items already in the set, which must not be repeated
+	Hard    bool     // boss battle: ask for harder items than a normal session
+	CEFR    string   // difficulty target such as "B1"; empty for none
+}
+
+// QuestionProvider produces raw question payloads for a mode.
+type QuestionProvider interface {
+	FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error)
+}
+
+// TavernEvaluator judges the player's tavern utterances against the rubric.
+type TavernEvaluator interface {
+	BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error)
+}
+
+// Provider is the full backend the game modes depend on.
+type Provider interface {
+	QuestionProvider
+	TavernEvaluator
+}
+
+// NewProvider returns the question backend selected by the saved config.
+// QUESTION_BACKEND, when set, overrides config.Config.Backend. Network
+// backends are cached in SQLite and fall back to the offline packs when a
+// fetch fails; a missing Gemini key selects the packs outright.
+func NewProvider(ctx context.Context) (Provider, error) {
+	cfg, _ := config.LoadConfig()
+	backend := cfg.Backend
+	if env := os.Getenv("QUESTION_BACKEND"); env != "" {
+		backend = env
+	}
+	packs := NewPackProvider(packsDir(cfg))
+	switch backend {
+	case "", BackendGemini:
+		gc, err := NewGeminiClient(ctx)
+		if err != nil {
+			log.Printf("Gemini unavailable, using offline packs: %v", err)
+			return packs, nil
+		}
+		return fallbackProvider{primary: NewCachedProvider(gc), fallback: packs}, nil
+	case BackendOpenAI:
+		oc, err := NewOpenAIClient(openAISettings(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return fallbackProvider{primary: NewCachedProvider(oc), fallback: packs}, nil
+	case BackendOffline:
+		return packs, nil
+	default:
+		return nil, fmt.Errorf("unknown question backend: %s", backend)
+	}
+}
+
+// packsDir resolves the offline pack directory: PACKS_DIR, then config, then
+// the default next to config.json.
+func packsDir(cfg config.Config) string {
+	if v := os.Getenv("PACKS_DIR"); v != "" {
+		return v
+	}
+	if cfg.PacksDir != "" {
+		return cfg.PacksDir
+	}
+	p, err := config.PacksPath()
+	if err != nil {
+		return ""
+	}
+	return p
+}
+
+// textGenerator is implemented by backends that answer a single free-form prompt.
+type textGenerator interface {
+	// generateText answers prompt; a non-nil schema asks for JSON matching it.
+	generateText(ctx context.Context, prompt string, schema *jsonSchema) (string, error)
+}
+
+// fetchQuestionsWith renders the mode prompt, sends it to gen and extracts the JSON payload.
+func fetchQuestionsWith(ctx context.Context, gen textGenerator, req QuestionRequest) (QuestionPayload, error) {
+	prompt, err := buildQuestionPrompt(req)
+	if err != nil {
+		return QuestionPayload{}, err
+	}
+	schema, err := questionSchema(req.Mode)
+	if err != nil {
+		return QuestionPayload{}, err
+	}
+	text, err := gen.generateText(ctx, prompt, schema)
+	if err != nil {
+		return QuestionPayload{}, err
+	}
+	return payloadFromText(req, text)
+}
+
+// batchEvaluateWith evaluates N tavern turns in one request to gen.
+// Generation failures degrade to "normal" outcomes rather than an error.
+func batchEvaluateWith(ctx context.Context, gen textGenerator, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	N := sessionQuestionCount()
+	if len(npcReplies) != N || len(playerUtterances) != N {
+		return nil, fmt.Errorf("expected %d npcReplies and %d playerUtterances", N, N)
+	}
+
+	prompt := buildBatchEvalPrompt(rubric, npcOpening, npcReplies, playerUtterances, langPref)
+
+	text, err := gen.generateText(ctx, prompt, schemaOf("tavern_evaluations", batchEvalEnvelope{}))
+	if err != nil {
+		return fallbackEvaluations(len(playerUtterances), err), nil
+	}
+	return parseBatchEvaluations(text, len(npcReplies)), nil
+}
+
+// NewQuestionRequest builds a request for mode using the saved preferences
+// and the active profile's CEFR target.
+func NewQuestionRequest(ctx context.Context, mode string) QuestionRequest {
+	cfg, _ := config.LoadConfig()
+	lang := "en"
+	if cfg.LangPref == "ja" {
+		lang = "ja"
+	}
+	return QuestionRequest{Mode: mode, Count: questionsPerSessionFrom(cfg), Lang: lang, CEFR: targetCEFR(ctx, mode)}
+}
+
+// sessionQuestionCount returns the configured number of questions per session.
+func sessionQuestionCount() int {
+	cfg, _ := config.LoadConfig()
+	return questionsPerSessionFrom(cfg)
+}
+
+func questionsPerSessionFrom(cfg config.Config) int {
+	if cfg.QuestionsPerSession > 0 {
+		return cfg.QuestionsPerSession
+	}
+	return 5
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeProvider struct {
+	content []byte
+	err     error
+	reqs    []QuestionRequest
+}
+
+func (f *fakeProvider) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return QuestionPayload{}, f.err
+	}
+	return QuestionPayload{Mode: req.Mode, Content: f.content}, nil
+}
+
+func (f *fakeProvider) BatchEvaluateTavern(ctx context.Context, rubric []string, npcOpening string, npcReplies []TavernTurn, playerUtterances []string, langPref string) ([]TavernEvaluation, error) {
+	return fallbackEvaluations(len(playerUtterances), errors.New("not implemented")), nil
+}
+
+const fiveSpellingPrompts = `{"prompts":[
+	{"ja_hint":"維持する","correct_spelling":"maintain","explanation":""},
+	{"ja_hint":"必要な","correct_spelling":"necessary","explanation":""},
+	{"ja_hint":"受け取る","correct_spelling":"receive","explanation":""},
+	{"ja_hint":"分ける","correct_spelling":"separate","explanation":""},
+	{"ja_hint":"環境","correct_spelling":"environment","explanation":""}
+]}`
+
+func TestFetchAndValidate_UsesProvider(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp := &fakeProvider{content: []byte(fiveSpellingPrompts)}
+	payload, err := FetchAndValidate(context.Background(), fp, ModeSpelling)
+	if err != nil {
+		t.Fatalf("FetchAndValidate error: %v", err)
+	}
+	if payload.Mode != ModeSpelling {
+		t.Fatalf("expected mode %s, got %s", ModeSpelling, payload.Mode)
+	}
+	if len(fp.reqs) != 1 || fp.reqs[0].Count != 5 {
+		t.Fatalf("expected one request for 5 items, got %+v", fp.reqs)
+	}
+}
+
+func TestFetchAndValidate_RejectsInvalidPayload(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	fp := &fakeProvider{content: []byte(`{"prompts":[]}`)}
+	if _, err := FetchAndValidate(context.Background(), fp, ModeSpelling); err == nil {
+		t.Fatalf("expected validation error for empty prompts")
+	}
+}
+
+func TestFetchAndValidate_NilProvider(t *testing.T) {
+	_, err := FetchAndValidate(context.Background(), nil, ModeVocab)
+	if !errors.Is(err, ErrNoProvider) {
+		t.Fatalf("expected ErrNoProvider, got %v", err)
+	}
+}
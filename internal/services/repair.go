@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxRepairAttempts bounds how many follow-up requests a fetch may make to
+// replace missing or invalid items.
+const maxRepairAttempts = 3
+
+// repairBackoff is the delay before the first follow-up request; it doubles
+// on every further attempt.
+var repairBackoff = 200 * time.Millisecond
+
+// repairingProvider is implemented by wrappers whose FetchQuestions already
+// repairs payloads, so callers do not retry on top of them.
+type repairingProvider interface {
+	repairsPayloads()
+}
+
+// fetchRepaired fetches req from p and repairs the payload: options are
+// deduplicated, invalid and duplicate items are dropped, and the missing
+// items are requested again (excluding those already kept) until the set is
+// complete or the attempts run out.
+func fetchRepaired(ctx context.Context, p QuestionProvider, req QuestionRequest) (QuestionPayload, error) {
+	if _, ok := p.(repairingProvider); ok {
+		return p.FetchQuestions(ctx, req)
+	}
+	set, err := newRepairSet(req.Mode, req.Count)
+	if err != nil {
+		return QuestionPayload{Mode: req.Mode}, err
+	}
+
+	next := req
+	delay := repairBackoff
+	var errs []error
+	for attempt := 0; ; attempt++ {
+		payload, err := p.FetchQuestions(ctx, next)
+		if err != nil {
+			return payload, errors.Join(append(errs, err)...)
+		}
+		if err := set.merge(payload.Content); err != nil {
+			errs = append(errs, err)
+		}
+		missing := set.missing()
+		if missing == 0 {
+			content, err := set.encode()
+			if err != nil {
+				return QuestionPayload{Mode: req.Mode}, err
+			}
+			return QuestionPayload{Mode: req.Mode, Content: content}, nil
+		}
+		if attempt == maxRepairAttempts {
+			errs = append(errs, fmt.Errorf("%d of %d items still missing after %d repair attempts", missing, req.Count, attempt))
+			return payload, errors.Join(errs...)
+		}
+
+		select {
+		case <-ctx.Done():
+			return payload, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		next = req
+		next.Count = missing
+		next.Exclude = set.keys()
+	}
+}
+
+// repairSet accumulates valid items across follow-up requests.
+type repairSet interface {
+	merge(raw []byte) error
+	missing() int
+	keys() []string
+	encode() ([]byte, error)
+}
+
+func newRepairSet(mode string, n int) (repairSet, error) {
+	if n <= 0 {
+		n = 5
+	}
+	switch mode {
+	case ModeVocab:
+		return &itemSet[VocabEnvelope, VocabQuestion]{
+			n:    n,
+			list: func(e *VocabEnvelope) *[]VocabQuestion { return &e.Questions },
+			key:  func(q VocabQuestion) string { return q.Word },
+			fix:  func(q *VocabQuestion) bool { return fixChoice(&q.Options, &q.AnswerIndex) },
+		}, nil
+	case ModeGrammar:
+		return &itemSet[GrammarEnvelope, GrammarTrap]{
+			n:    n,
+			list: func(e *GrammarEnvelope) *[]GrammarTrap { return &e.Traps },
+			key:  grammarKey,
+			fix:  func(t *GrammarTrap) bool { return fixChoice(&t.Options, &t.AnswerIndex) },
+		}, nil
+	case ModeSpelling:
+		return &itemSet[SpellingEnvelope, SpellingPrompt]{
+			n:    n,
+			list: func(e *SpellingEnvelope) *[]SpellingPrompt { return &e.Prompts },
+			key:  func(p SpellingPrompt) string { return p.CorrectSpelling },
+			fix:  func(p *SpellingPrompt) bool { return strings.TrimSpace(p.JAHint) != "" },
+		}, nil
+	case ModeListening:
+		return &itemSet[ListeningEnvelope, ListeningItem]{
+			n:    n,
+			list: func(e *ListeningEnvelope) *[]ListeningItem { return &e.Audio },
+			key:  func(a ListeningItem) string { return a.Prompt },
+			fix:  func(a *ListeningItem) bool { return fixChoice(&a.Options, &a.AnswerIndex) },
+		}, nil
+	case ModeTavern:
+		return &tavernSet{n: n}, nil
+	default:
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+}
+
+// itemSet keeps the valid, distinct items of a list-shaped envelope E.
+type itemSet[E any, T any] struct {
+	n     int
+	items []T
+	seen  []string
+	list  func(*E) *[]T // the envelope's item list
+	key   func(T) string
+	fix   func(*T) bool // repairs the item in place and reports whether it is usable
+}
+
+func (s *itemSet[E, T]) merge(raw []byte) error {
+	var env E
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	for _, it := range *s.list(&env) {
+		if len(s.items) == s.n {
+			break
+		}
+		k := normalizeKey(s.key(it))
+		if k == "" || s.has(k) || !s.fix(&it) {
+			continue
+		}
+		s.items = append(s.items, it)
+		s.seen = append(s.seen, k)
+	}
+	return nil
+}
+
+func (s *itemSet[E, T]) has(k string) bool {
+	for _, seen := range s.seen {
+		if seen == k {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *itemSet[E, T]) missing() int { return s.n - len(s.items) }
+
+func (s *itemSet[E, T]) keys() []string {
+	keys := make([]string, len(s.items))
+	for i, it := range s.items {
+		keys[i] = s.key(it)
+	}
+	return keys
+}
+
+func (s *itemSet[E, T]) encode() ([]byte, error) {
+	var env E
+	*s.list(&env) = s.items
+	return json.Marshal(env)
+}
+
+// tavernSet keeps the first usable scene; turns from different scenes
+// cannot be mixed, so an incomplete scene is requested again in full.
+type tavernSet struct {
+	n     int
+	scene *TavernEnvelope
+}
+
+func (s *tavernSet) merge(raw []byte) error {
+	var env TavernEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("invalid tavern JSON: %w", err)
+	}
+	if s.scene != nil || len(env.Turns) < s.n || len(env.EvaluationRubric) < 3 {
+		return nil
+	}
+	env.Turns = env.Turns[:s.n]
+	s.scene = &env
+	return nil
+}
+
+func (s *tavernSet) missing() int {
+	if s.scene == nil {
+		return s.n
+	}
+	return 0
+}
+
+func (s *tavernSet) keys() []string { return nil }
+
+func (s *tavernSet) encode() ([]byte, error) { return json.Marshal(s.scene) }
+
+// fixChoice removes duplicate options, keeps answer pointing at the same
+// text, and reports whether four distinct options with a valid answer remain.
+func fixChoice(opts *[]string, answer *int) bool {
+	if *answer < 0 || *answer >= len(*opts) {
+		return false
+	}
+	correct := normalizeKey((*opts)[*answer])
+	if correct == "" {
+		return false
+	}
+	out := make([]string, 0, len(*opts))
+	seen := make(map[string]bool, len(*opts))
+	for _, o := range *opts {
+		k := normalizeKey(o)
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		if k == correct {
+			*answer = len(out)
+		}
+		out = append(out, o)
+	}
+	*opts = out
+	return len(out) == 4
+}
+
+// grammarKey identifies a trap by its question and correct sentence, since
+// many traps share a generic stem such as "Which sentence is correct?".
+func grammarKey(t GrammarTrap) string {
+	if t.AnswerIndex < 0 || t.AnswerIndex >= len(t.Options) {
+		return t.Question
+	}
+	return t.Question + " / " + t.Options[t.AnswerIndex]
+}
+
+func normalizeKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// scriptedProvider returns its payloads in order, repeating the last one.
+type scriptedProvider struct {
+	payloads []string
+	reqs     []QuestionRequest
+}
+
+func (s *scriptedProvider) FetchQuestions(ctx context.Context, req QuestionRequest) (QuestionPayload, error) {
+	s.reqs = append(s.reqs, req)
+	i := len(s.reqs) - 1
+	if i >= len(s.payloads) {
+		i = len(s.payloads) - 1
+	}
+	return QuestionPayload{Mode: req.Mode, Content: []byte(s.payloads[i])}, nil
+}
+
+func noRepairBackoff(t *testing.T) {
+	t.Helper()
+	old := repairBackoff
+	repairBackoff = 0
+	t.Cleanup(func() { repairBackoff = old })
+}
+
+func TestFetchAndValidate_RepairsMissingItems(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	noRepairBackoff(t)
+	sp := &scriptedProvider{payloads: []string{
+		`{"questions":[
+			{"word":"brave","options":["bold","Bold","shy","slow"],"answer_index":0},
+			{"word":"calm","options":["quiet","loud","fast","red"],"answer_index":0},
+			{"word":"Calm","options":["quiet","loud","fast","red"],"answer_index":0},
+			{"word":"eager","options":["keen","lazy","dull","sad"],"answer_index":7},
+			{"word":"fierce","options":["wild","meek","soft","kind"],"answer_index":0}]}`,
+		`{"questions":[
+			{"word":"calm","options":["quiet","loud","fast","red"],"answer_index":0},
+			{"word":"gentle","options":["kind","harsh","rude","cold"],"answer_index":0},
+			{"word":"humble","options":["modest","proud","vain","loud"],"answer_index":0},
+			{"word":"idle","options":["lazy","busy","keen","fast"],"answer_index":0}]}`,
+	}}
+
+	payload, err := FetchAndValidate(context.Background(), sp, ModeVocab)
+	if err != nil {
+		t.Fatalf("FetchAndValidate error: %v", err)
+	}
+	if len(sp.reqs) != 2 {
+		t.Fatalf("expected one follow-up request, got %d requests", len(sp.reqs))
+	}
+	follow := sp.reqs[1]
+	if follow.Count != 3 || !reflect.DeepEqual(follow.Exclude, []string{"calm", "fierce"}) {
+		t.Fatalf("unexpected follow-up request: %+v", follow)
+	}
+	var env VocabEnvelope
+	if err := json.Unmarshal(payload.Content, &env); err != nil {
+		t.Fatalf("invalid repaired payload: %v", err)
+	}
+	var words []string
+	for _, q := range env.Questions {
+		words = append(words, q.Word)
+	}
+	if want := []string{"calm", "fierce", "gentle", "humble", "idle"}; !reflect.DeepEqual(words, want) {
+		t.Fatalf("words = %v, want %v", words, want)
+	}
+}
+
+func TestFetchAndValidate_GivesUpAfterRepairAttempts(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	noRepairBackoff(t)
+	sp := &scriptedProvider{payloads: []string{`{"prompts":[]}`}}
+	if _, err := FetchAndValidate(context.Background(), sp, ModeSpelling); err == nil {
+		t.Fatal("expected error for a backend that never returns items")
+	}
+	if len(sp.reqs) != maxRepairAttempts+1 {
+		t.Fatalf("expected %d requests, got %d", maxRepairAttempts+1, len(sp.reqs))
+	}
+}
+
+func TestFetchRepaired_FetchErrorIsNotRetried(t *testing.T) {
+	noRepairBackoff(t)
+	fp := &fakeProvider{err: errors.New("offline")}
+	if _, err := fetchRepaired(context.Background(), fp, QuestionRequest{Mode: ModeVocab, Count: 5}); err == nil {
+		t.Fatal("expected fetch error")
+	}
+	if len(fp.reqs) != 1 {
+		t.Fatalf("expected a single request, got %d", len(fp.reqs))
+	}
+}
+
+func TestFixChoice(t *testing.T) {
+	opts := []string{"a", "b", " B", "c", "d"}
+	answer := 3
+	if !fixChoice(&opts, &answer) {
+		t.Fatalf("expected four options after dedup, got %v", opts)
+	}
+	if answer != 2 || opts[answer] != "c" {
+		t.Fatalf("answer moved to %d (%v)", answer, opts)
+	}
+
+	opts, answer = []string{"a", "a", "b", "c"}, 0
+	if fixChoice(&opts, &answer) {
+		t.Fatalf("three distinct options should be rejected: %v", opts)
+	}
+}
+
+func TestFetchRepaired_TavernTruncatesTurns(t *testing.T) {
+	noRepairBackoff(t)
+	sp := &scriptedProvider{payloads: []string{
+		`{"npc_name":"Jaro","npc_opening":"Hi","evaluation_rubric":["s","n","f"],"turns":[{"npc_reply":"1"},{"npc_reply":"2"}]}`,
+		`{"npc_name":"Jaro","npc_opening":"Hi","evaluation_rubric":["s","n","f"],"turns":[{"npc_reply":"1"},{"npc_reply":"2"},{"npc_reply":"3"},{"npc_reply":"4"}]}`,
+	}}
+	payload, err := fetchRepaired(context.Background(), sp, QuestionRequest{Mode: ModeTavern, Count: 3})
+	if err != nil {
+		t.Fatalf("fetchRepaired error: %v", err)
+	}
+	var env TavernEnvelope
+	if err := json.Unmarshal(payload.Content, &env); err != nil || len(env.Turns) != 3 {
+		t.Fatalf("expected 3 turns, got %d (%v)", len(env.Turns), err)
+	}
+	if sp.reqs[1].Count != 3 {
+		t.Fatalf("tavern follow-up should ask for a full scene: %+v", sp.reqs[1])
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// jsonSchema is the subset of JSON Schema accepted by both Gemini's response
+// schema and OpenAI's structured outputs.
+type jsonSchema struct {
+	name string // identifier some backends require for the top-level schema
+
+	Type                 string                 `json:"type"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// schemaOf derives a schema from the JSON shape of v. Every field with a json
+// tag is required; an `enum:"a,b"` tag restricts a string field.
+func schemaOf(name string, v any) *jsonSchema {
+	s := schemaForType(reflect.TypeOf(v))
+	s.name = name
+	return s
+}
+
+func schemaForType(t reflect.Type) *jsonSchema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaForType(t.Elem())
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Struct:
+		closed := false
+		s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}, AdditionalProperties: &closed}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			fs := schemaForType(f.Type)
+			if enum := f.Tag.Get("enum"); enum != "" {
+				fs.Enum = strings.Split(enum, ",")
+			}
+			s.Properties[name] = fs
+			s.Required = append(s.Required, name)
+		}
+		return s
+	default:
+		panic(fmt.Sprintf("schemaOf: unsupported kind %s", t.Kind()))
+	}
+}
+
+// questionSchema returns the response schema of the envelope for mode.
+func questionSchema(mode string) (*jsonSchema, error) {
+	switch mode {
+	case ModeVocab:
+		return schemaOf("vocab_envelope", VocabEnvelope{}), nil
+	case ModeGrammar:
+		return schemaOf("grammar_envelope", GrammarEnvelope{}), nil
+	case ModeTavern:
+		return schemaOf("tavern_envelope", TavernEnvelope{}), nil
+	case ModeSpelling:
+		return schemaOf("spelling_envelope", SpellingEnvelope{}), nil
+	case ModeListening:
+		return schemaOf("listening_envelope", ListeningEnvelope{}), nil
+	default:
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+}
+
+// directJSON reports whether text is exactly one JSON object, as it is under
+// structured output.
+func directJSON(text string) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		return trimmed, true
+	}
+	return "", false
+}
+
+// extractJSON returns the JSON object in text, falling back to the brace
+// scanner for backends that wrap it in prose or markdown.
+func extractJSON(text string) (string, bool) {
+	if obj, ok := directJSON(text); ok {
+		return obj, true
+	}
+	return findJSONBlock(text)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestSchemaOf_VocabEnvelope(t *testing.T) {
+	s := schemaOf("vocab_envelope", VocabEnvelope{})
+	if s.Type != "object" || !reflect.DeepEqual(s.Required, []string{"questions"}) {
+		t.Fatalf("unexpected envelope schema: %+v", s)
+	}
+	item := s.Properties["questions"].Items
+	if item == nil || item.Type != "object" {
+		t.Fatalf("questions should be an array of objects: %+v", s.Properties["questions"])
+	}
+	want := []string{"enemy_name", "word", "options", "answer_index", "explanation"}
+	if !reflect.DeepEqual(item.Required, want) {
+		t.Fatalf("required = %v, want %v", item.Required, want)
+	}
+	if item.Properties["answer_index"].Type != "integer" || item.Properties["options"].Items.Type != "string" {
+		t.Fatalf("unexpected field types: %+v", item.Properties)
+	}
+	if item.AdditionalProperties == nil || *item.AdditionalProperties {
+		t.Fatal("objects should be closed")
+	}
+}
+
+func TestSchemaOf_EnumAndGenai(t *testing.T) {
+	s := schemaOf("tavern_evaluations", batchEvalEnvelope{})
+	outcome := s.Properties["evaluations"].Items.Properties["outcome"]
+	if !reflect.DeepEqual(outcome.Enum, []string{"success", "normal", "fail"}) {
+		t.Fatalf("outcome enum = %v", outcome.Enum)
+	}
+	gs := genaiSchema(s)
+	got := gs.Properties["evaluations"].Items.Properties["outcome"]
+	if gs.Type != genai.TypeObject || got.Type != genai.TypeString || got.Format != "enum" || len(got.Enum) != 3 {
+		t.Fatalf("unexpected genai schema: %+v", got)
+	}
+}
+
+func TestOpenAIClient_SendsResponseSchema(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var req chatRequest
+	srv := newChatServer(t, fiveSpellingPrompts, &req)
+	defer srv.Close()
+
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: srv.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	if _, err := FetchAndValidate(context.Background(), oc, ModeSpelling); err != nil {
+		t.Fatalf("FetchAndValidate error: %v", err)
+	}
+	rf := req.ResponseFormat
+	if rf == nil || rf.Type != "json_schema" || rf.JSONSchema.Name != "spelling_envelope" || !rf.JSONSchema.Strict {
+		t.Fatalf("unexpected response_format: %+v", rf)
+	}
+	if _, ok := rf.JSONSchema.Schema.Properties["prompts"]; !ok {
+		t.Fatalf("schema missing prompts: %+v", rf.JSONSchema.Schema)
+	}
+}
+
+func TestOpenAIClient_SchemaRejected(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req chatRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.ResponseFormat != nil {
+			http.Error(w, "response_format not supported", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "Here:\n" + fiveSpellingPrompts}}},
+		})
+	}))
+	defer srv.Close()
+
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := FetchAndValidate(context.Background(), oc, ModeSpelling); err != nil {
+			t.Fatalf("FetchAndValidate error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected one rejected call then plain requests, got %d calls", calls)
+	}
+}
+
+func TestPayloadFromText_DirectAndScanned(t *testing.T) {
+	req := QuestionRequest{Mode: ModeSpelling, Count: 5}
+	direct, err := payloadFromText(req, "  "+fiveSpellingPrompts+"\n")
+	if err != nil || !json.Valid(direct.Content) {
+		t.Fatalf("direct JSON: %v", err)
+	}
+	wrapped, err := payloadFromText(req, "Sure!\n```json\n"+fiveSpellingPrompts+"\n```")
+	if err != nil {
+		t.Fatalf("wrapped JSON: %v", err)
+	}
+	if string(direct.Content) != string(wrapped.Content) {
+		t.Fatalf("payloads differ:\n%s\n%s", direct.Content, wrapped.Content)
+	}
+}
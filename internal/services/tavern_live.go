@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLiveTavernUnsupported is returned when the backend cannot hold a live
+// tavern conversation; callers fall back to the scripted turns.
+var ErrLiveTavernUnsupported = errors.New("live tavern conversation not supported by backend")
+
+// TavernLine is one line of the running tavern transcript.
+type TavernLine struct {
+	Speaker string // "npc" or "player"
+	Text    string
+}
+
+// TavernReplyRequest carries the conversation so far and the player's newest utterance.
+type TavernReplyRequest struct {
+	NPCName    string
+	NPCOpening string
+	Rubric     []string
+	Transcript []TavernLine // every line before the utterance, starting with the NPC's first line
+	Utterance  string
+	Turn       int // 1-based turn the utterance answers
+	TotalTurns int
+	Lang       string // language for the evaluation reason
+}
+
+// TavernReply is the NPC's contextual answer plus the grade of the utterance.
+type TavernReply struct {
+	Evaluation TavernEvaluation `json:"evaluation"`
+	NPCReply   string           `json:"npc_reply"`
+}
+
+// LiveTavern is implemented by backends that answer each tavern turn as it happens.
+type LiveTavern interface {
+	TavernReply(ctx context.Context, req TavernReplyRequest) (TavernReply, error)
+}
+
+// SupportsLiveTavern reports whether p can hold a live tavern conversation.
+func SupportsLiveTavern(p Provider) bool {
+	if p == nil {
+		return false
+	}
+	lt, ok := p.(LiveTavern)
+	if !ok {
+		return false
+	}
+	if s, ok := lt.(interface{ supportsLiveTavern() bool }); ok {
+		return s.supportsLiveTavern()
+	}
+	return true
+}
+
+// RequestTavernReply asks p for the NPC's next line and a grade of req.Utterance.
+func RequestTavernReply(ctx context.Context, p Provider, req TavernReplyRequest) (TavernReply, error) {
+	if p == nil {
+		return TavernReply{}, ErrNoProvider
+	}
+	lt, ok := p.(LiveTavern)
+	if !ok {
+		return TavernReply{}, ErrLiveTavernUnsupported
+	}
+	return lt.TavernReply(ctx, req)
+}
+
+// tavernReplyWith sends the running transcript to gen and parses the reply.
+func tavernReplyWith(ctx context.Context, gen textGenerator, req TavernReplyRequest) (TavernReply, error) {
+	text, err := gen.generateText(ctx, buildTavernReplyPrompt(req), schemaOf("tavern_reply", TavernReply{}))
+	if err != nil {
+		return TavernReply{}, err
+	}
+	return parseTavernReply(text)
+}
+
+func buildTavernReplyPrompt(req TavernReplyRequest) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("You are %s, an NPC in a fantasy tavern in an English-learning RPG. ", req.NPCName))
+	b.WriteString("Stay in character, keep replies to one or two short sentences of natural English, and respond to what the player actually said.\n\n")
+	b.WriteString("Scene opening:\n" + req.NPCOpening + "\n\n")
+
+	b.WriteString("Evaluation rubric:\n")
+	for i, r := range req.Rubric {
+		b.WriteString(fmt.Sprintf("%d. %s\n", i+1, r))
+	}
+	b.WriteString("\nConversation so far:\n")
+	for _, line := range req.Transcript {
+		speaker := req.NPCName
+		if line.Speaker == "player" {
+			speaker = "Player"
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", speaker, line.Text))
+	}
+	b.WriteString(fmt.Sprintf("Player (turn %d of %d): %s\n\n", req.Turn, req.TotalTurns, req.Utterance))
+
+	b.WriteString("Judge the player's latest line against the rubric, then write your next line. ")
+	if req.Turn >= req.TotalTurns {
+		b.WriteString("This was the final turn, so close the conversation politely. ")
+	}
+	if req.Lang == "ja" {
+		b.WriteString("Write the evaluation reason in Japanese. ")
+	} else {
+		b.WriteString("Write the evaluation reason in English. ")
+	}
+	b.WriteString("Return only valid JSON with this format:\n")
+	b.WriteString(`{"evaluation":{"outcome":"success|normal|fail","reason":"short reason"},"npc_reply":"your next line"}` + "\n")
+	return b.String()
+}
+
+func parseTavernReply(text string) (TavernReply, error) {
+	extracted, ok := extractJSON(text)
+	if !ok {
+		return TavernReply{}, fmt.Errorf("could not extract JSON from tavern reply: %s", text)
+	}
+	var r TavernReply
+	if err := json.Unmarshal([]byte(extracted), &r); err != nil {
+		return TavernReply{}, fmt.Errorf("invalid tavern reply JSON: %w", err)
+	}
+	switch r.Evaluation.Outcome {
+	case "success", "normal", "fail":
+	default:
+		return TavernReply{}, fmt.Errorf("invalid outcome: %s", r.Evaluation.Outcome)
+	}
+	if strings.TrimSpace(r.NPCReply) == "" {
+		return TavernReply{}, errors.New("tavern reply has no npc_reply")
+	}
+	return r, nil
+}
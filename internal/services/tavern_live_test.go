@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIClient_TavernReply(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var got chatRequest
+	srv := newChatServer(t, "```json\n"+`{"evaluation":{"outcome":"success","reason":"clear order"},"npc_reply":"One ale, coming up. Anything to eat?"}`+"\n```", &got)
+	defer srv.Close()
+
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: srv.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	req := TavernReplyRequest{
+		NPCName:    "Mira",
+		NPCOpening: "The tavern is busy tonight.",
+		Transcript: []TavernLine{{Speaker: "npc", Text: "What can I get you?"}},
+		Utterance:  "I'd like an ale, please.",
+		Turn:       1,
+		TotalTurns: 5,
+		Lang:       "en",
+	}
+	reply, err := RequestTavernReply(context.Background(), NewCachedProvider(oc), req)
+	if err != nil {
+		t.Fatalf("RequestTavernReply error: %v", err)
+	}
+	if reply.Evaluation.Outcome != "success" || !strings.HasPrefix(reply.NPCReply, "One ale") {
+		t.Fatalf("unexpected reply: %+v", reply)
+	}
+	prompt := got.Messages[0].Content
+	for _, want := range []string{"Mira: What can I get you?", "Player (turn 1 of 5): I'd like an ale, please."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestParseTavernReply_Invalid(t *testing.T) {
+	for _, text := range []string{
+		"no json here",
+		`{"evaluation":{"outcome":"great","reason":"x"},"npc_reply":"hi"}`,
+		`{"evaluation":{"outcome":"normal","reason":"x"},"npc_reply":"  "}`,
+	} {
+		if _, err := parseTavernReply(text); err == nil {
+			t.Errorf("expected error for %q", text)
+		}
+	}
+}
+
+func TestSupportsLiveTavern(t *testing.T) {
+	packs := NewPackProvider(t.TempDir())
+	if SupportsLiveTavern(packs) || SupportsLiveTavern(nil) {
+		t.Fatal("packs should not support live tavern")
+	}
+	if SupportsLiveTavern(NewCachedProvider(packs)) {
+		t.Fatal("cached packs should not support live tavern")
+	}
+	oc, err := NewOpenAIClient(OpenAISettings{BaseURL: "http://localhost"})
+	if err != nil {
+		t.Fatalf("NewOpenAIClient error: %v", err)
+	}
+	if !SupportsLiveTavern(fallbackProvider{primary: NewCachedProvider(oc), fallback: packs}) {
+		t.Fatal("online backend behind the cache and fallback should support live tavern")
+	}
+	if _, err := RequestTavernReply(context.Background(), packs, TavernReplyRequest{}); !errors.Is(err, ErrLiveTavernUnsupported) {
+		t.Fatalf("expected ErrLiveTavernUnsupported, got %v", err)
+	}
+}
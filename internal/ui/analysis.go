@@ -3,10 +3,13 @@ package ui
 import (
 	"context" // Add context import
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/db"
 	"tui-english-quest/internal/game"
 	"tui-english-quest/internal/i18n"
 	"tui-english-quest/internal/services"
@@ -22,28 +25,39 @@ var (
 
 // AnalysisModel displays the AI weakness analysis.
 type AnalysisModel struct {
-	playerStats  game.Stats
-	report       services.WeaknessReport
-	geminiClient *services.GeminiClient // Add GeminiClient
+	playerStats game.Stats
+	report      services.WeaknessReport
+	provider    services.Provider
+	charts      bool // show progress charts instead of the report
+	windowIdx   int  // index into services.ProgressWindows
+	progress    services.ProgressReport
+	progressErr error
 }
 
 // NewAnalysisModel creates a new AnalysisModel.
-func NewAnalysisModel(stats game.Stats, gc *services.GeminiClient) AnalysisModel {
-	report, err := services.AnalyzeWeakness(context.Background(), gc, stats.Name, stats, 200)
+func NewAnalysisModel(stats game.Stats, p services.Provider) AnalysisModel {
+	report, err := services.AnalyzeWeakness(context.Background(), p, db.CurrentProfileID(), stats, 200)
 	if err != nil {
 		report = services.WeaknessReport{
 			Recommendation: fmt.Sprintf("Error analyzing weakness: %v", err),
 		}
 	}
 	return AnalysisModel{
-		playerStats:  stats,
-		report:       report,
-		geminiClient: gc,
+		playerStats: stats,
+		report:      report,
+		provider:    p,
 	}
 }
 
+// Init saves the report shown on entering the screen to the analysis history.
 func (m AnalysisModel) Init() tea.Cmd {
-	return nil
+	report := m.report
+	return func() tea.Msg {
+		if err := services.RecordAnalysis(context.Background(), db.CurrentProfileID(), report); err != nil {
+			log.Printf("failed to save analysis: %v", err)
+		}
+		return nil
+	}
 }
 
 func (m AnalysisModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -52,14 +66,39 @@ func (m AnalysisModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "esc", "enter":
 			return m, func() tea.Msg { return AnalysisToTownMsg{} }
+		case "c":
+			m.charts = !m.charts
+			if m.charts {
+				m = m.loadProgress()
+			}
+		case "w":
+			if m.charts {
+				m.windowIdx = (m.windowIdx + 1) % len(services.ProgressWindows)
+				m = m.loadProgress()
+			}
 		}
 	}
 	return m, nil
 }
 
+func (m AnalysisModel) loadProgress() AnalysisModel {
+	window := services.ProgressWindows[m.windowIdx]
+	m.progress, m.progressErr = services.BuildProgress(context.Background(), db.CurrentProfileID(), window, time.Now())
+	return m
+}
+
 func (m AnalysisModel) View() string {
 	s := m.playerStats
 	header := components.Header(s, true, 0)
+	if m.charts {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			header,
+			lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""), // Separator
+			analysisStyle.Render(m.viewCharts()),
+			lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""), // Separator
+			components.Footer(i18n.T("footer_analysis_charts"), 0),
+		)
+	}
 
 	var b strings.Builder
 	b.WriteString(analysisTitleStyle.Render(i18n.T("analysis_title") + "\n"))
@@ -133,6 +172,62 @@ func (m AnalysisModel) View() string {
 	)
 }
 
+// viewCharts renders daily EXP, per-mode accuracy and weekly faints for the
+// selected window.
+func (m AnalysisModel) viewCharts() string {
+	p := m.progress
+	var b strings.Builder
+	b.WriteString(analysisTitleStyle.Render(fmt.Sprintf(i18n.T("analysis_charts_title"), services.ProgressWindows[m.windowIdx])) + "\n")
+	if m.progressErr != nil {
+		b.WriteString("\n" + analysisItemStyle.Render(fmt.Sprintf(i18n.T("analysis_charts_error"), m.progressErr)))
+		return b.String()
+	}
+	if p.Sessions == 0 {
+		b.WriteString("\n" + analysisItemStyle.Render(i18n.T("analysis_charts_empty")))
+		return b.String()
+	}
+	b.WriteString(analysisItemStyle.Render(fmt.Sprintf(i18n.T("analysis_charts_totals"), p.Sessions, p.TotalExp)) + "\n")
+
+	exp := make([]float64, len(p.Exp))
+	maxExp := 0
+	for i, v := range p.Exp {
+		exp[i] = float64(v)
+		maxExp = max(maxExp, v)
+	}
+	title := fmt.Sprintf(i18n.T("analysis_chart_exp"), maxExp)
+	if p.BucketDays > 1 {
+		title = fmt.Sprintf(i18n.T("analysis_chart_exp_bucket"), p.BucketDays, maxExp)
+	}
+	b.WriteString(analysisSectionStyle.Render("\n"+title) + "\n")
+	b.WriteString(analysisItemStyle.Render(components.Sparkline(exp, 0, components.ColorAccent)) + "\n")
+
+	b.WriteString(analysisSectionStyle.Render("\n"+i18n.T("analysis_chart_accuracy")) + "\n")
+	labelStyle := lipgloss.NewStyle().Width(24)
+	for _, mode := range p.Modes {
+		series := p.Accuracy[mode]
+		latest := ""
+		for i := len(series) - 1; i >= 0; i-- {
+			if series[i] >= 0 {
+				latest = fmt.Sprintf("%3.0f%%", series[i]*100)
+				break
+			}
+		}
+		row := labelStyle.Render(modeLabel(mode)) + components.Sparkline(series, 1, components.ColorInfo) + "  " + latest
+		b.WriteString(analysisItemStyle.Render(row) + "\n")
+	}
+
+	b.WriteString(analysisSectionStyle.Render("\n"+i18n.T("analysis_chart_faints")) + "\n")
+	maxFaints := 0
+	for _, n := range p.Faints {
+		maxFaints = max(maxFaints, n)
+	}
+	for i, week := range p.Weeks {
+		row := fmt.Sprintf("%s  %s %d", week.Format("01/02"), components.Bar(p.Faints[i], maxFaints, 20, components.ColorDanger), p.Faints[i])
+		b.WriteString(analysisItemStyle.Render(row) + "\n")
+	}
+	return b.String()
+}
+
 func formatTrend(trend float64) string {
 	switch {
 	case trend > 0.015:
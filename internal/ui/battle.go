@@ -31,7 +31,7 @@ var (
 // BattleModel represents the vocabulary battle screen.
 type BattleModel struct {
 	playerStats     game.Stats
-	geminiClient    *services.GeminiClient
+	provider        services.Provider
 	questions       []services.VocabQuestion
 	currentQuestion int
 	answerInput     textinput.Model
@@ -42,11 +42,14 @@ type BattleModel struct {
 	showFeedback bool
 	quitting     bool
 	hpAnimator   HPAnimator
-	answers      []game.VocabAnswer // To store answers for RunVocabSession
+	answers      []game.VocabAnswer       // To store answers for RunVocabSession
+	review       []services.VocabQuestion // Due review items served instead of fetching
+	timer        QuestionTimer
+	gear         game.Effects // gear bonuses the session is settled with
 }
 
 // NewBattleModel creates a new BattleModel.
-func NewBattleModel(stats game.Stats, gc *services.GeminiClient) BattleModel {
+func NewBattleModel(stats game.Stats, p services.Provider) BattleModel {
 	ti := textinput.New()
 	ti.Placeholder = i18n.T("battle_placeholder")
 	ti.Focus()
@@ -55,7 +58,7 @@ func NewBattleModel(stats game.Stats, gc *services.GeminiClient) BattleModel {
 
 	return BattleModel{
 		playerStats:     stats,
-		geminiClient:    gc,
+		provider:        p,
 		questions:       []services.VocabQuestion{},
 		currentQuestion: 0,
 		answerInput:     ti,
@@ -67,9 +70,19 @@ func NewBattleModel(stats game.Stats, gc *services.GeminiClient) BattleModel {
 		quitting:     false,
 		hpAnimator:   NewHPAnimator(stats.HP),
 		answers:      make([]game.VocabAnswer, 0, 5), // Initialize answers slice
+		timer:        NewQuestionTimer(),
+		gear:         game.EquipmentEffects(context.Background(), services.ModeVocab),
 	}
 }
 
+// NewBattleReviewModel creates a BattleModel that serves the given review
+// questions instead of fetching a fresh set.
+func NewBattleReviewModel(stats game.Stats, questions []services.VocabQuestion) BattleModel {
+	m := NewBattleModel(stats, nil)
+	m.review = questions
+	return m
+}
+
 // BattleQuestionMsg is a message to indicate questions have been fetched.
 type BattleQuestionMsg struct {
 	Questions []services.VocabQuestion
@@ -81,8 +94,12 @@ func (m BattleModel) Init() tea.Cmd {
 }
 
 func (m BattleModel) fetchQuestionsCmd() tea.Cmd {
+	if m.review != nil {
+		qs := m.review
+		return func() tea.Msg { return BattleQuestionMsg{Questions: qs} }
+	}
 	return func() tea.Msg {
-		payload, err := services.FetchAndValidate(context.Background(), m.mode)
+		payload, err := services.FetchAndValidate(context.Background(), m.provider, m.mode)
 		if err != nil {
 			return BattleQuestionMsg{Err: err}
 		}
@@ -118,7 +135,18 @@ func (m BattleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.questions = msg.Questions
-		return m, nil
+		if len(m.questions) == 0 {
+			return m, nil
+		}
+		return m, m.timer.Start()
+
+	case questionTickMsg:
+		expired, cmd := m.timer.Tick(msg)
+		if expired && !m.showFeedback {
+			m.answerInput.SetValue("")
+			return m.submitAnswer(true)
+		}
+		return m, cmd
 
 	case hpTickMsg:
 		return m, m.hpAnimator.Tick(m.playerStats.HP)
@@ -142,46 +170,9 @@ func (m BattleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				m.currentQuestion++
-				return m, nil
+				return m, m.timer.Start()
 			}
-
-			// Process answer
-			// Defensive: ensure currentQuestion is within bounds
-			if m.currentQuestion < 0 || m.currentQuestion >= len(m.questions) {
-				// Out-of-range state: ignore input and reset feedback
-				m.feedback = i18n.T("battle_error_state")
-				m.showFeedback = true
-				m.isCorrect = false
-				return m, nil
-			}
-			currentQ := m.questions[m.currentQuestion]
-			isCorrect := (m.answerInput.Value() == currentQ.Options[currentQ.AnswerIndex])
-			m.answers = append(m.answers, game.VocabAnswer{Correct: isCorrect})
-
-			// If this was the last answer, finalize session immediately
-			if len(m.answers) == len(m.questions) {
-				return m.finalizeVocabSession()
-			}
-
-			if isCorrect {
-				m.feedback = i18n.T("correct_feedback")
-				m.isCorrect = true
-				// TODO: Update player stats (EXP, Combo, etc.) - will be handled by RunVocabSession at session end
-			} else {
-				m.feedback = fmt.Sprintf(i18n.T("battle_incorrect_answer"), currentQ.Options[currentQ.AnswerIndex])
-				m.isCorrect = false
-				prevHP := m.playerStats.HP
-				// Immediate HP update for UX: compute damage and apply to playerStats
-				m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
-				M := game.AllowedMisses(len(m.questions))
-				dmg := game.DamagePerMiss(m.playerStats.MaxHP, M)
-				m.playerStats = game.ApplyDamage(m.playerStats, dmg)
-				m.playerStats = game.ResetCombo(m.playerStats)
-				m.showFeedback = true
-				return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
-			}
-			m.showFeedback = true
-			return m, nil
+			return m.submitAnswer(false)
 
 			// case "up", "k": // removed
 			// 	if !m.answerInput.Focused() && m.selectedOption > 0 {
@@ -202,8 +193,63 @@ func (m BattleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submitAnswer grades the typed answer for the current question. timedOut
+// marks an answer forced by the countdown running out.
+func (m BattleModel) submitAnswer(timedOut bool) (BattleModel, tea.Cmd) {
+	// Defensive: ensure currentQuestion is within bounds
+	if m.currentQuestion < 0 || m.currentQuestion >= len(m.questions) {
+		// Out-of-range state: ignore input and reset feedback
+		m.feedback = i18n.T("battle_error_state")
+		m.showFeedback = true
+		m.isCorrect = false
+		return m, nil
+	}
+	currentQ := m.questions[m.currentQuestion]
+	isCorrect := (m.answerInput.Value() == currentQ.Options[currentQ.AnswerIndex])
+	m.answers = append(m.answers, game.VocabAnswer{Correct: isCorrect, Item: game.QuestionLog{
+		Prompt:        currentQ.Word,
+		Options:       currentQ.Options,
+		Chosen:        m.answerInput.Value(),
+		CorrectAnswer: currentQ.Options[currentQ.AnswerIndex],
+		Explanation:   currentQ.Explanation,
+		Latency:       m.timer.Stop(),
+	}})
+
+	// If this was the last answer, finalize session immediately
+	if len(m.answers) == len(m.questions) {
+		return m.finalizeVocabSession()
+	}
+
+	if isCorrect {
+		m.feedback = i18n.T("correct_feedback")
+		m.isCorrect = true
+		// TODO: Update player stats (EXP, Combo, etc.) - will be handled by RunVocabSession at session end
+	} else {
+		m.feedback = fmt.Sprintf(i18n.T("battle_incorrect_answer"), currentQ.Options[currentQ.AnswerIndex])
+		if timedOut {
+			m.feedback = i18n.T("timer_time_up") + " " + m.feedback
+		}
+		m.isCorrect = false
+		prevHP := m.playerStats.HP
+		// Immediate HP update for UX: compute damage and apply to playerStats
+		m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
+		M := game.AllowedMisses(len(m.questions))
+		dmg := m.gear.Damage(game.DamagePerMiss(m.playerStats.MaxHP, M))
+		m.playerStats = game.ApplyDamage(m.playerStats, dmg)
+		m.playerStats = game.ResetCombo(m.playerStats)
+		m.showFeedback = true
+		return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
+	}
+	m.showFeedback = true
+	return m, nil
+}
+
 func (m BattleModel) finalizeVocabSession() (BattleModel, tea.Cmd) {
-	updatedStats, summary, err := game.RunVocabSession(context.Background(), m.playerStats, m.answers)
+	ctx := context.Background()
+	if m.review != nil {
+		ctx = game.WithReview(ctx)
+	}
+	updatedStats, summary, err := game.RunVocabSession(ctx, m.playerStats, m.answers)
 	if err != nil {
 		m.feedback = fmt.Sprintf("Session error: %v", err)
 		m.showFeedback = true
@@ -250,6 +296,9 @@ func (m BattleModel) View() string {
 
 		currentQ := m.questions[m.currentQuestion]
 		questionText := questionStyle.Render(fmt.Sprintf(i18n.T("battle_question_format"), m.currentQuestion+1, len(m.questions), currentQ.Word))
+		if t := m.timer.View(); t != "" {
+			questionText = lipgloss.JoinVertical(lipgloss.Left, t, questionText)
+		}
 
 		// Calculate content width based on header width
 		contentWidth := lipgloss.Width(header) - battleStyle.GetHorizontalPadding()
@@ -0,0 +1,400 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/services"
+	"tui-english-quest/internal/ui/components"
+)
+
+var (
+	bossStyle         = lipgloss.NewStyle().Padding(1, 2)
+	bossTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(components.ColorDanger)
+	bossDescStyle     = lipgloss.NewStyle().Foreground(components.ColorMuted)
+	bossLockedStyle   = lipgloss.NewStyle().Foreground(components.ColorMuted)
+	bossQuestionStyle = lipgloss.NewStyle().Foreground(components.ColorInfo).PaddingBottom(1)
+	bossCorrectStyle  = lipgloss.NewStyle().Foreground(components.ColorPrimary)
+	bossWrongStyle    = lipgloss.NewStyle().Foreground(components.ColorDanger)
+)
+
+// bossHPBarWidth is the width of the boss HP bar in cells.
+const bossHPBarWidth = 30
+
+// TownToBossMsg signals to the RootModel to open the Boss Lair.
+type TownToBossMsg struct{}
+
+// BossToTownMsg signals to the RootModel to return to Town.
+type BossToTownMsg struct{}
+
+// bossQuestion is one vocabulary or grammar question of a boss battle.
+type bossQuestion struct {
+	Mode        string
+	Prompt      string
+	Options     []string
+	AnswerIndex int
+	Explanation string
+}
+
+// BossQuestionMsg carries the fetched boss battle questions.
+type BossQuestionMsg struct {
+	Questions []bossQuestion
+	Err       error
+}
+
+// BossModel lists the tier bosses and runs a battle against the chosen one.
+type BossModel struct {
+	playerStats  game.Stats
+	startStats   game.Stats // stats the battle is settled against
+	provider     services.Provider
+	bosses       []game.BossStatus
+	cursor       int
+	boss         game.Boss
+	fighting     bool
+	questions    []bossQuestion
+	current      int
+	selected     int
+	bossHP       int
+	potions      int
+	gear         game.Effects // gear bonuses the battle is settled with
+	answers      []game.BossAnswer
+	feedback     string
+	isCorrect    bool
+	showFeedback bool
+	note         string
+	quitting     bool
+	hpAnimator   HPAnimator
+	timer        QuestionTimer
+}
+
+// NewBossModel loads the bosses and the active profile's victories.
+func NewBossModel(stats game.Stats, p services.Provider) BossModel {
+	m := BossModel{
+		playerStats: stats,
+		provider:    p,
+		hpAnimator:  NewHPAnimator(stats.HP),
+		timer:       NewQuestionTimer(),
+	}
+	bosses, err := game.BossProgress(context.Background(), stats)
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("boss_error"), err)
+	}
+	m.bosses = bosses
+	return m
+}
+
+func (m BossModel) Init() tea.Cmd {
+	return nil
+}
+
+// fetchQuestionsCmd fetches a hard vocabulary set and a hard grammar set and
+// interleaves them into one battle.
+func (m BossModel) fetchQuestionsCmd() tea.Cmd {
+	p := m.provider
+	return func() tea.Msg {
+		ctx := context.Background()
+		req := services.NewQuestionRequest(ctx, services.ModeVocab)
+		req.Hard = true
+		payload, err := services.FetchAndValidateRequest(ctx, p, req)
+		if err != nil {
+			return BossQuestionMsg{Err: err}
+		}
+		var vocab services.VocabEnvelope
+		if err := json.Unmarshal(payload.Content, &vocab); err != nil {
+			return BossQuestionMsg{Err: fmt.Errorf("failed to parse vocab questions: %w", err)}
+		}
+
+		req = services.NewQuestionRequest(ctx, services.ModeGrammar)
+		req.Hard = true
+		payload, err = services.FetchAndValidateRequest(ctx, p, req)
+		if err != nil {
+			return BossQuestionMsg{Err: err}
+		}
+		var grammar services.GrammarEnvelope
+		if err := json.Unmarshal(payload.Content, &grammar); err != nil {
+			return BossQuestionMsg{Err: fmt.Errorf("failed to parse grammar questions: %w", err)}
+		}
+		return BossQuestionMsg{Questions: interleaveBossQuestions(vocab.Questions, grammar.Traps)}
+	}
+}
+
+// interleaveBossQuestions alternates vocabulary and grammar questions.
+func interleaveBossQuestions(vocab []services.VocabQuestion, grammar []services.GrammarTrap) []bossQuestion {
+	out := make([]bossQuestion, 0, len(vocab)+len(grammar))
+	for i := 0; i < max(len(vocab), len(grammar)); i++ {
+		if i < len(vocab) {
+			q := vocab[i]
+			out = append(out, bossQuestion{Mode: services.ModeVocab, Prompt: q.Word, Options: q.Options, AnswerIndex: q.AnswerIndex, Explanation: q.Explanation})
+		}
+		if i < len(grammar) {
+			q := grammar[i]
+			out = append(out, bossQuestion{Mode: services.ModeGrammar, Prompt: q.Question, Options: q.Options, AnswerIndex: q.AnswerIndex, Explanation: q.Explanation})
+		}
+	}
+	return out
+}
+
+func (m BossModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case BossQuestionMsg:
+		if msg.Err != nil {
+			m.fighting = false
+			m.note = fmt.Sprintf(i18n.T("error_fetching_questions"), msg.Err)
+			return m, nil
+		}
+		m.questions = msg.Questions
+		if len(m.questions) == 0 {
+			m.fighting = false
+			return m, nil
+		}
+		return m, m.timer.Start()
+
+	case questionTickMsg:
+		expired, cmd := m.timer.Tick(msg)
+		if expired && !m.showFeedback {
+			m.selected = -1
+			return m.submitAnswer(true)
+		}
+		return m, cmd
+
+	case hpTickMsg:
+		return m, m.hpAnimator.Tick(m.playerStats.HP)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "esc":
+			return m, func() tea.Msg { return BossToTownMsg{} }
+		}
+		if !m.fighting {
+			return m.updateLair(msg)
+		}
+		if len(m.questions) == 0 {
+			return m, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "down", "j":
+			if m.selected < len(m.questions[m.current].Options)-1 {
+				m.selected++
+			}
+		case "1", "2", "3", "4":
+			if m.showFeedback {
+				return m, nil
+			}
+			m.selected = int(msg.String()[0] - '1')
+			return m.submitAnswer(false)
+		case "enter":
+			if !m.showFeedback {
+				return m.submitAnswer(false)
+			}
+			m.showFeedback = false
+			m.selected = 0
+			if m.bossHP == 0 || m.playerStats.HP == 0 || m.current+1 >= len(m.questions) {
+				return m.finalizeBossSession()
+			}
+			m.current++
+			return m, m.timer.Start()
+		}
+	}
+	return m, nil
+}
+
+// updateLair handles the boss list.
+func (m BossModel) updateLair(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "q":
+		return m, func() tea.Msg { return BossToTownMsg{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.bosses)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if m.cursor >= len(m.bosses) {
+			return m, nil
+		}
+		b := m.bosses[m.cursor]
+		if !b.Unlocked {
+			m.note = fmt.Sprintf(i18n.T("boss_locked"), b.MinLevel)
+			return m, nil
+		}
+		potions, err := game.HPPotions(context.Background())
+		if err != nil {
+			potions = 0
+		}
+		m.boss = b.Boss
+		m.bossHP = b.HP
+		m.potions = potions
+		m.gear = game.EquipmentEffects(context.Background(), "boss")
+		m.startStats = m.playerStats
+		m.fighting = true
+		m.note = ""
+		return m, m.fetchQuestionsCmd()
+	}
+	return m, nil
+}
+
+// submitAnswer grades the selected option for the current question. timedOut
+// marks an answer forced by the countdown running out.
+func (m BossModel) submitAnswer(timedOut bool) (BossModel, tea.Cmd) {
+	q := m.questions[m.current]
+	isCorrect := m.selected == q.AnswerIndex
+	chosen := ""
+	if m.selected >= 0 && m.selected < len(q.Options) {
+		chosen = q.Options[m.selected]
+	}
+	m.answers = append(m.answers, game.BossAnswer{Correct: isCorrect, Item: game.QuestionLog{
+		Prompt:        q.Prompt,
+		Options:       q.Options,
+		Chosen:        chosen,
+		CorrectAnswer: q.Options[q.AnswerIndex],
+		Explanation:   q.Explanation,
+		Latency:       m.timer.Stop(),
+	}})
+	m.isCorrect = isCorrect
+	m.showFeedback = true
+
+	if isCorrect {
+		hit := m.boss.HitDamage(len(m.questions))
+		m.bossHP = max(m.bossHP-hit, 0)
+		m.feedback = fmt.Sprintf(i18n.T("boss_hit"), hit)
+		return m, nil
+	}
+	m.feedback = fmt.Sprintf(i18n.T("boss_incorrect_answer"), q.Options[q.AnswerIndex])
+	if timedOut {
+		m.feedback = i18n.T("timer_time_up") + " " + m.feedback
+	}
+	// The damage shown here is settled by RunBossSession against startStats.
+	prevHP := m.playerStats.HP
+	m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
+	M := game.AllowedMisses(len(m.questions))
+	m.playerStats = game.ApplyDamage(m.playerStats, m.gear.Damage(game.DamagePerMiss(m.playerStats.MaxHP, M)))
+	m.playerStats = game.ResetCombo(m.playerStats)
+	if m.playerStats.HP == 0 && m.potions > 0 {
+		m.potions--
+		m.playerStats.HP = game.PotionHeal(m.playerStats)
+	}
+	return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
+}
+
+func (m BossModel) finalizeBossSession() (BossModel, tea.Cmd) {
+	updatedStats, summary, err := game.RunBossSession(context.Background(), m.startStats, m.boss, len(m.questions), m.answers)
+	if err != nil {
+		m.feedback = fmt.Sprintf(i18n.T("boss_error"), err)
+		m.showFeedback = true
+		return m, nil
+	}
+	m.playerStats = updatedStats
+	m.hpAnimator.Sync(m.playerStats.HP)
+	return m, func() tea.Msg { return SessionResultMsg{Stats: m.playerStats, Summary: summary} }
+}
+
+func (m BossModel) View() string {
+	if m.quitting {
+		return i18n.T("exiting_message") + "\n"
+	}
+
+	displayStats := m.playerStats
+	displayStats.HP = m.hpAnimator.Display()
+	header := components.Header(displayStats, true, 0)
+
+	var content, footer string
+	if m.fighting {
+		content = m.battleView()
+		footer = components.Footer(i18n.T("footer_boss_battle"), 0)
+	} else {
+		content = m.lairView()
+		footer = components.Footer(i18n.T("footer_boss"), 0)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		bossStyle.Render(lipgloss.NewStyle().Width(lipgloss.Width(header)-bossStyle.GetHorizontalPadding()).Render(content)),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
+
+// lairView lists the bosses with their unlock level and the player's wins.
+func (m BossModel) lairView() string {
+	var b strings.Builder
+	b.WriteString(bossTitleStyle.Render(i18n.T("boss_title")) + "\n")
+	b.WriteString(bossDescStyle.Render(i18n.T("boss_intro")) + "\n\n")
+	labels := make([]string, len(m.bosses))
+	for i, boss := range m.bosses {
+		labels[i] = bossLabel(boss)
+	}
+	b.WriteString(components.Menu(labels, m.cursor, 1, 0))
+	if m.cursor < len(m.bosses) {
+		boss := m.bosses[m.cursor]
+		desc := fmt.Sprintf(i18n.T("boss_desc"), boss.Tier, boss.ExpReward, boss.GoldReward)
+		if !boss.Unlocked {
+			desc += "\n" + fmt.Sprintf(i18n.T("boss_locked"), boss.MinLevel)
+		}
+		b.WriteString("\n" + bossDescStyle.Render(desc) + "\n")
+	}
+	if m.note != "" {
+		b.WriteString("\n" + bossDescStyle.Render(m.note))
+	}
+	return b.String()
+}
+
+// bossLabel renders a boss menu entry, e.g. "👑 Stone Golem  Lv 20+  (wins: 2)".
+func bossLabel(b game.BossStatus) string {
+	label := fmt.Sprintf("%s  Lv %d+", i18n.T("boss_"+b.ID), b.MinLevel)
+	if !b.Unlocked {
+		return bossLockedStyle.Render("🔒 " + label)
+	}
+	if b.Victories > 0 {
+		label += "  " + fmt.Sprintf(i18n.T("boss_wins"), b.Victories)
+	}
+	return "👑 " + label
+}
+
+// battleView renders the boss HP bar and the current question.
+func (m BossModel) battleView() string {
+	name := i18n.T("boss_" + m.boss.ID)
+	bar := fmt.Sprintf("%s  %s %s", bossTitleStyle.Render(name), components.HPBar(m.bossHP, m.boss.HP, bossHPBarWidth), components.HPText(m.bossHP, m.boss.HP))
+	if len(m.questions) == 0 {
+		return bar + "\n\n" + i18n.FetchingFor("boss")
+	}
+
+	q := m.questions[m.current]
+	var b strings.Builder
+	b.WriteString(bar + "\n\n")
+	if t := m.timer.View(); t != "" {
+		b.WriteString(t + "\n")
+	}
+	b.WriteString(bossQuestionStyle.Render(fmt.Sprintf(i18n.T("boss_question_progress"), modeLabel(q.Mode), m.current+1, len(m.questions), q.Prompt)) + "\n")
+	for i, o := range q.Options {
+		prefix := "  "
+		if i == m.selected {
+			prefix = "> "
+		}
+		b.WriteString(fmt.Sprintf("%s%d) %s\n", prefix, i+1, o))
+	}
+	if m.showFeedback {
+		style := bossWrongStyle
+		if m.isCorrect {
+			style = bossCorrectStyle
+		}
+		b.WriteString("\n" + style.Render(m.feedback) + "\n" + i18n.T("press_enter_continue"))
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/ui/components"
+)
+
+// CharacterCreatedMsg carries the name and class chosen on the character
+// creation screen.
+type CharacterCreatedMsg struct {
+	Name  string
+	Class string
+}
+
+// CharacterCancelledMsg reports that character creation was abandoned.
+type CharacterCancelledMsg struct{}
+
+// CharacterModel is the character creation screen: the player enters a name
+// and then picks one of game.Classes.
+type CharacterModel struct {
+	playerStats   game.Stats
+	nameInput     textinput.Model
+	choosingClass bool
+	cursor        int
+	note          string
+}
+
+// NewCharacterModel starts character creation with name prefilled.
+func NewCharacterModel(stats game.Stats, name string) CharacterModel {
+	ti := textinput.New()
+	ti.Placeholder = i18n.T("character_name_placeholder")
+	ti.CharLimit = 24
+	ti.Width = 30
+	ti.SetValue(name)
+	ti.Focus()
+	return CharacterModel{playerStats: stats, nameInput: ti}
+}
+
+func (m CharacterModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m CharacterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		if !m.choosingClass {
+			var cmd tea.Cmd
+			m.nameInput, cmd = m.nameInput.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if !m.choosingClass {
+		switch key.String() {
+		case "esc":
+			return m, func() tea.Msg { return CharacterCancelledMsg{} }
+		case "enter":
+			if strings.TrimSpace(m.nameInput.Value()) == "" {
+				m.note = i18n.T("character_name_required")
+				return m, nil
+			}
+			m.choosingClass = true
+			m.nameInput.Blur()
+			m.note = ""
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.nameInput, cmd = m.nameInput.Update(msg)
+		return m, cmd
+	}
+
+	switch key.String() {
+	case "esc":
+		m.choosingClass = false
+		return m, m.nameInput.Focus()
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(game.Classes)-1 {
+			m.cursor++
+		}
+	case "enter":
+		created := CharacterCreatedMsg{
+			Name:  strings.TrimSpace(m.nameInput.Value()),
+			Class: game.Classes[m.cursor].Name,
+		}
+		return m, func() tea.Msg { return created }
+	}
+	return m, nil
+}
+
+func (m CharacterModel) View() string {
+	header := components.Header(m.playerStats, true, 0)
+
+	var b strings.Builder
+	b.WriteString(profilesTitleStyle.Render(i18n.T("character_title")) + "\n\n")
+	if !m.choosingClass {
+		b.WriteString(i18n.T("character_name_prompt") + "\n\n")
+		b.WriteString(m.nameInput.View() + "\n")
+	} else {
+		b.WriteString(fmt.Sprintf(i18n.T("character_class_prompt"), strings.TrimSpace(m.nameInput.Value())) + "\n\n")
+		labels := make([]string, len(game.Classes))
+		for i, c := range game.Classes {
+			bonus := int(math.Round(c.ExpBonus * 100))
+			labels[i] = c.Name + "  " + fmt.Sprintf(i18n.T("class_desc_"+c.Mode), bonus)
+		}
+		b.WriteString(components.Menu(labels, m.cursor, 1, 0))
+	}
+	if m.note != "" {
+		b.WriteString("\n" + profilesNoteStyle.Render(m.note))
+	}
+
+	footerKey := "footer_character_name"
+	if m.choosingClass {
+		footerKey = "footer_character_class"
+	}
+	footer := components.Footer(i18n.T(footerKey), 0)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		profilesStyle.Render(b.String()),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
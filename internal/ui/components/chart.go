@@ -0,0 +1,49 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders one block per value, scaled between 0 and max. Negative
+// values mark missing data and render as a dot. A max of 0 scales to the
+// largest value.
+func Sparkline(values []float64, max float64, color lipgloss.TerminalColor) string {
+	if max <= 0 {
+		for _, v := range values {
+			if v > max {
+				max = v
+			}
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			b.WriteRune('·')
+		case max <= 0:
+			b.WriteRune(sparkLevels[0])
+		default:
+			i := int(v / max * float64(len(sparkLevels)-1))
+			i = min(i, len(sparkLevels)-1)
+			b.WriteRune(sparkLevels[i])
+		}
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(b.String())
+}
+
+// Bar renders value as a horizontal bar of up to width cells against max.
+func Bar(value, max, width int, color lipgloss.TerminalColor) string {
+	filled := 0
+	if max > 0 {
+		filled = value * width / max
+	}
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	filled = min(filled, width)
+	return lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) + strings.Repeat(" ", width-filled)
+}
@@ -30,7 +30,7 @@ var (
 // DungeonModel represents the grammar dungeon screen.
 type DungeonModel struct {
 	playerStats     game.Stats
-	geminiClient    *services.GeminiClient
+	provider        services.Provider
 	questions       []services.GrammarTrap
 	currentQuestion int
 	answerInput     textinput.Model
@@ -40,11 +40,14 @@ type DungeonModel struct {
 	showFeedback    bool
 	quitting        bool
 	hpAnimator      HPAnimator
-	answers         []game.GrammarAnswer // To store answers for RunGrammarSession
+	answers         []game.GrammarAnswer   // To store answers for RunGrammarSession
+	review          []services.GrammarTrap // Due review items served instead of fetching
+	timer           QuestionTimer
+	gear            game.Effects // gear bonuses the session is settled with
 }
 
 // NewDungeonModel creates a new DungeonModel.
-func NewDungeonModel(stats game.Stats, gc *services.GeminiClient) DungeonModel {
+func NewDungeonModel(stats game.Stats, p services.Provider) DungeonModel {
 	ti := textinput.New()
 	ti.Placeholder = i18n.T("dungeon_placeholder")
 	ti.Focus()
@@ -53,7 +56,7 @@ func NewDungeonModel(stats game.Stats, gc *services.GeminiClient) DungeonModel {
 
 	return DungeonModel{
 		playerStats:     stats,
-		geminiClient:    gc,
+		provider:        p,
 		questions:       []services.GrammarTrap{},
 		currentQuestion: 0,
 		answerInput:     ti,
@@ -64,9 +67,19 @@ func NewDungeonModel(stats game.Stats, gc *services.GeminiClient) DungeonModel {
 		quitting:        false,
 		hpAnimator:      NewHPAnimator(stats.HP),
 		answers:         make([]game.GrammarAnswer, 0, 5), // Initialize answers slice
+		timer:           NewQuestionTimer(),
+		gear:            game.EquipmentEffects(context.Background(), services.ModeGrammar),
 	}
 }
 
+// NewDungeonReviewModel creates a DungeonModel that serves the given review
+// traps instead of fetching a fresh set.
+func NewDungeonReviewModel(stats game.Stats, traps []services.GrammarTrap) DungeonModel {
+	m := NewDungeonModel(stats, nil)
+	m.review = traps
+	return m
+}
+
 // DungeonQuestionMsg is a message to indicate questions have been fetched.
 type DungeonQuestionMsg struct {
 	Questions []services.GrammarTrap
@@ -78,8 +91,12 @@ func (m DungeonModel) Init() tea.Cmd {
 }
 
 func (m DungeonModel) fetchQuestionsCmd() tea.Cmd {
+	if m.review != nil {
+		qs := m.review
+		return func() tea.Msg { return DungeonQuestionMsg{Questions: qs} }
+	}
 	return func() tea.Msg {
-		payload, err := services.FetchAndValidate(context.Background(), m.mode)
+		payload, err := services.FetchAndValidate(context.Background(), m.provider, m.mode)
 		if err != nil {
 			return DungeonQuestionMsg{Err: err}
 		}
@@ -114,7 +131,18 @@ func (m DungeonModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.questions = msg.Questions
-		return m, nil
+		if len(m.questions) == 0 {
+			return m, nil
+		}
+		return m, m.timer.Start()
+
+	case questionTickMsg:
+		expired, cmd := m.timer.Tick(msg)
+		if expired && !m.showFeedback {
+			m.answerInput.SetValue("")
+			return m.submitAnswer(true)
+		}
+		return m, cmd
 
 	case hpTickMsg:
 		return m, m.hpAnimator.Tick(m.playerStats.HP)
@@ -138,46 +166,9 @@ func (m DungeonModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				m.currentQuestion++
-				return m, nil
+				return m, m.timer.Start()
 			}
-
-			// Process answer
-			// Defensive: ensure currentQuestion is within bounds
-			if m.currentQuestion < 0 || m.currentQuestion >= len(m.questions) {
-				m.feedback = i18n.T("dungeon_error_state")
-				m.showFeedback = true
-				m.isCorrect = false
-				return m, nil
-			}
-			currentQ := m.questions[m.currentQuestion]
-			isCorrect := (m.answerInput.Value() == currentQ.Options[currentQ.AnswerIndex])
-			m.answers = append(m.answers, game.GrammarAnswer{Correct: isCorrect})
-
-			// Auto-finalize when answers reach configured count
-			if len(m.answers) == len(m.questions) {
-				return m.finalizeGrammarSession()
-			}
-
-			if isCorrect {
-				m.feedback = i18n.T("correct_feedback")
-				m.isCorrect = true
-				// TODO: Update player stats (EXP, Combo, etc.) - will be handled by RunGrammarSession
-			} else {
-				m.feedback = fmt.Sprintf(i18n.T("dungeon_incorrect_answer"), currentQ.Options[currentQ.AnswerIndex])
-				m.isCorrect = false
-				prevHP := m.playerStats.HP
-				// Immediate HP update for UX
-				m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
-				M := game.AllowedMisses(len(m.questions))
-				dmg := game.DamagePerMiss(m.playerStats.MaxHP, M)
-				m.playerStats = game.ApplyDamage(m.playerStats, dmg)
-				m.playerStats = game.ResetCombo(m.playerStats)
-				m.showFeedback = true
-				return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
-			}
-			m.showFeedback = true
-			return m, nil
-
+			return m.submitAnswer(false)
 		}
 	}
 
@@ -189,8 +180,62 @@ func (m DungeonModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submitAnswer grades the typed answer for the current floor. timedOut marks
+// an answer forced by the countdown running out.
+func (m DungeonModel) submitAnswer(timedOut bool) (DungeonModel, tea.Cmd) {
+	// Defensive: ensure currentQuestion is within bounds
+	if m.currentQuestion < 0 || m.currentQuestion >= len(m.questions) {
+		m.feedback = i18n.T("dungeon_error_state")
+		m.showFeedback = true
+		m.isCorrect = false
+		return m, nil
+	}
+	currentQ := m.questions[m.currentQuestion]
+	isCorrect := (m.answerInput.Value() == currentQ.Options[currentQ.AnswerIndex])
+	m.answers = append(m.answers, game.GrammarAnswer{Correct: isCorrect, Item: game.QuestionLog{
+		Prompt:        currentQ.Question,
+		Options:       currentQ.Options,
+		Chosen:        m.answerInput.Value(),
+		CorrectAnswer: currentQ.Options[currentQ.AnswerIndex],
+		Explanation:   currentQ.Explanation,
+		Latency:       m.timer.Stop(),
+	}})
+
+	// Auto-finalize when answers reach configured count
+	if len(m.answers) == len(m.questions) {
+		return m.finalizeGrammarSession()
+	}
+
+	if isCorrect {
+		m.feedback = i18n.T("correct_feedback")
+		m.isCorrect = true
+		// TODO: Update player stats (EXP, Combo, etc.) - will be handled by RunGrammarSession
+	} else {
+		m.feedback = fmt.Sprintf(i18n.T("dungeon_incorrect_answer"), currentQ.Options[currentQ.AnswerIndex])
+		if timedOut {
+			m.feedback = i18n.T("timer_time_up") + " " + m.feedback
+		}
+		m.isCorrect = false
+		prevHP := m.playerStats.HP
+		// Immediate HP update for UX
+		m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
+		M := game.AllowedMisses(len(m.questions))
+		dmg := m.gear.Damage(game.DamagePerMiss(m.playerStats.MaxHP, M))
+		m.playerStats = game.ApplyDamage(m.playerStats, dmg)
+		m.playerStats = game.ResetCombo(m.playerStats)
+		m.showFeedback = true
+		return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
+	}
+	m.showFeedback = true
+	return m, nil
+}
+
 func (m DungeonModel) finalizeGrammarSession() (DungeonModel, tea.Cmd) {
-	updatedStats, summary, err := game.RunGrammarSession(context.Background(), m.playerStats, m.answers)
+	ctx := context.Background()
+	if m.review != nil {
+		ctx = game.WithReview(ctx)
+	}
+	updatedStats, summary, err := game.RunGrammarSession(ctx, m.playerStats, m.answers)
 	if err != nil {
 		m.feedback = fmt.Sprintf("Session error: %v", err)
 		m.showFeedback = true
@@ -237,6 +282,9 @@ func (m DungeonModel) View() string {
 
 		currentQ := m.questions[m.currentQuestion]
 		questionText := questionStyleDungeon.Render(fmt.Sprintf(i18n.T("dungeon_question_progress"), m.currentQuestion+1, len(m.questions), currentQ.Question))
+		if t := m.timer.View(); t != "" {
+			questionText = lipgloss.JoinVertical(lipgloss.Left, t, questionText)
+		}
 
 		// Calculate content width based on header width
 		contentWidth := lipgloss.Width(header) - dungeonStyle.GetHorizontalPadding()
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/ui/components"
+)
+
+var (
+	equipmentStyle      = lipgloss.NewStyle().Padding(1, 2)
+	equipmentTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
+	equipmentNoteStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted).Italic(true)
+)
+
+// TownToEquipmentMsg signals to the RootModel to open the Equipment screen.
+type TownToEquipmentMsg struct{}
+
+// EquipmentToTownMsg signals to the RootModel to return to Town.
+type EquipmentToTownMsg struct{}
+
+// EquipmentModel shows the four equipment slots and lets the player swap
+// the item worn in each from their inventory.
+type EquipmentModel struct {
+	playerStats game.Stats
+	equipped    map[string]db.EquipmentRecord
+	owned       []db.EquipmentRecord
+	cursor      int
+	picking     bool
+	choices     []db.EquipmentRecord // owned items for the selected slot
+	choice      int
+	note        string
+}
+
+// NewEquipmentModel loads the active profile's inventory and equipped items.
+func NewEquipmentModel(stats game.Stats) EquipmentModel {
+	return EquipmentModel{playerStats: stats}.reload()
+}
+
+func (m EquipmentModel) reload() EquipmentModel {
+	ctx := context.Background()
+	m.equipped = map[string]db.EquipmentRecord{}
+	items, err := game.EquippedItems(ctx)
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("equipment_error"), err)
+	}
+	for _, it := range items {
+		m.equipped[it.Slot] = it
+	}
+	m.owned, err = game.OwnedEquipment(ctx)
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("equipment_error"), err)
+	}
+	return m
+}
+
+func (m EquipmentModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m EquipmentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.picking {
+		return m.updatePicking(key)
+	}
+
+	switch key.String() {
+	case "q", "esc":
+		return m, func() tea.Msg { return EquipmentToTownMsg{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(game.Slots)-1 {
+			m.cursor++
+		}
+	case "enter":
+		slot := game.Slots[m.cursor]
+		m.choices = nil
+		for _, it := range m.owned {
+			if it.Slot == slot {
+				m.choices = append(m.choices, it)
+			}
+		}
+		m.choice = len(m.choices) // "remove" entry
+		for i, it := range m.choices {
+			if it.ID == m.equipped[slot].ID {
+				m.choice = i
+			}
+		}
+		m.picking = true
+		m.note = ""
+	}
+	return m, nil
+}
+
+func (m EquipmentModel) updatePicking(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "q", "esc":
+		m.picking = false
+	case "up", "k":
+		if m.choice > 0 {
+			m.choice--
+		}
+	case "down", "j":
+		if m.choice < len(m.choices) {
+			m.choice++
+		}
+	case "enter":
+		ctx := context.Background()
+		var err error
+		if m.choice == len(m.choices) {
+			err = game.Unequip(ctx, game.Slots[m.cursor])
+		} else {
+			err = game.Equip(ctx, m.choices[m.choice].ID)
+		}
+		m.picking = false
+		if err != nil {
+			m.note = fmt.Sprintf(i18n.T("equipment_error"), err)
+			return m, nil
+		}
+		m = m.reload()
+	}
+	return m, nil
+}
+
+func (m EquipmentModel) View() string {
+	header := components.Header(m.playerStats, true, 0)
+
+	var b strings.Builder
+	b.WriteString(equipmentTitleStyle.Render(i18n.T("equipment_title")) + "\n\n")
+	if !m.picking {
+		labels := make([]string, len(game.Slots))
+		for i, slot := range game.Slots {
+			item := i18n.T("equipment_empty")
+			if it, ok := m.equipped[slot]; ok {
+				item = equipmentLabel(it)
+			}
+			labels[i] = fmt.Sprintf("%-8s %s", i18n.T("equipment_slot_"+slot)+":", item)
+		}
+		b.WriteString(components.Menu(labels, m.cursor, 2, 0))
+	} else {
+		slot := game.Slots[m.cursor]
+		b.WriteString(fmt.Sprintf(i18n.T("equipment_pick_prompt"), i18n.T("equipment_slot_"+slot)) + "\n\n")
+		labels := make([]string, 0, len(m.choices)+1)
+		for _, it := range m.choices {
+			labels = append(labels, equipmentLabel(it))
+		}
+		labels = append(labels, i18n.T("equipment_remove"))
+		b.WriteString(components.Menu(labels, m.choice, 2, 0))
+	}
+	if m.note != "" {
+		b.WriteString("\n" + equipmentNoteStyle.Render(m.note))
+	}
+
+	footerKey := "footer_equipment"
+	if m.picking {
+		footerKey = "footer_equipment_pick"
+	}
+	footer := components.Footer(i18n.T(footerKey), 0)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		equipmentStyle.Render(b.String()),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
+
+// equipmentLabel renders an item with its effect, e.g.
+// "Iron Sword (+10% EXP, Vocabulary Battle)".
+func equipmentLabel(it db.EquipmentRecord) string {
+	pct := int(math.Round(it.EffectValue * 100))
+	effect := ""
+	switch it.EffectType {
+	case game.EffectExpBoost:
+		effect = fmt.Sprintf(i18n.T("equipment_effect_exp"), pct)
+	case game.EffectDamageReduction:
+		effect = fmt.Sprintf(i18n.T("equipment_effect_damage"), pct)
+	}
+	target := i18n.T("equipment_target_all")
+	if it.TargetMode != game.TargetAll {
+		target = i18n.T("result_title_" + it.TargetMode)
+	}
+	return fmt.Sprintf("%s (%s, %s)", it.Name, effect, target)
+}
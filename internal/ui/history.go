@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/cards"
+	"tui-english-quest/internal/config"
 	"tui-english-quest/internal/db"
 	"tui-english-quest/internal/game"
 	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/services"
 	"tui-english-quest/internal/ui/components"
 )
 
@@ -18,32 +22,91 @@ var (
 	historyTitleStyle  = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
 	historyItemStyle   = lipgloss.NewStyle().PaddingLeft(2)
 	historyHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorMuted)
+	historyNoteStyle   = lipgloss.NewStyle().Foreground(components.ColorInfo)
+	historyRightStyle  = lipgloss.NewStyle().Foreground(components.ColorPrimary)
+	historyWrongStyle  = lipgloss.NewStyle().Foreground(components.ColorDanger)
+	historyMutedStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted)
 )
 
-// HistoryModel displays the player's session history.
+const (
+	historyPageSize = 10
+	// historyDetailItems is how many questions the detail view shows at once.
+	historyDetailItems = 4
+)
+
+// historyModes are the mode filters m cycles through; "" shows every mode.
+var historyModes = []string{"", services.ModeVocab, services.ModeGrammar, services.ModeTavern, services.ModeSpelling, services.ModeListening, "boss"}
+
+// historyRanges are the period filters d cycles through, in days up to and
+// including today; 0 shows all time.
+var historyRanges = []int{0, 1, 7, 30, 90}
+
+// HistoryModel displays the player's session history one page at a time and
+// opens the question log of a session.
 type HistoryModel struct {
 	playerStats game.Stats
-	sessions    []db.SessionRecord
+	sessions    []db.SessionRecord // the current page
+	total       int                // sessions matching the filters
+	page        int
 	cursor      int
+	modeIdx     int
+	rangeIdx    int
+	note        string
+	detail      *historyDetail // nil while the list is shown
+}
+
+// historyDetail is the opened session with its question log.
+type historyDetail struct {
+	session db.SessionRecord
+	items   []db.SessionItem
+	scroll  int
 }
 
-// NewHistoryModel creates a new HistoryModel.
+// NewHistoryModel creates a new HistoryModel showing the newest sessions.
 func NewHistoryModel(stats game.Stats) HistoryModel {
+	return HistoryModel{playerStats: stats}.load()
+}
+
+// filter returns the database filter for the selected mode and period.
+func (m HistoryModel) filter(now time.Time) db.SessionFilter {
+	f := db.SessionFilter{Mode: historyModes[m.modeIdx]}
+	if days := historyRanges[m.rangeIdx]; days > 0 {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		f.Since = today.AddDate(0, 0, 1-days)
+	}
+	return f
+}
+
+func (m HistoryModel) pages() int {
+	return max((m.total+historyPageSize-1)/historyPageSize, 1)
+}
+
+// load reads the current page for the current filters.
+func (m HistoryModel) load() HistoryModel {
 	ctx := context.Background()
 	playerID := db.CurrentProfileID()
-	sessions := []db.SessionRecord{}
-	if playerID != "" {
-		var err error
-		sessions, err = db.ListSessions(ctx, playerID, 20)
-		if err != nil {
-			sessions = []db.SessionRecord{}
-		}
+	m.sessions, m.total = nil, 0
+	if playerID == "" {
+		return m
 	}
-	return HistoryModel{
-		playerStats: stats,
-		sessions:    sessions,
-		cursor:      0,
+	f := m.filter(time.Now())
+	total, err := db.CountSessions(ctx, playerID, f)
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("history_error"), err)
+		return m
 	}
+	m.total = total
+	if m.page >= m.pages() {
+		m.page = m.pages() - 1
+	}
+	m.sessions, err = db.ListSessionsPage(ctx, playerID, f, historyPageSize, m.page*historyPageSize)
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("history_error"), err)
+	}
+	if m.cursor >= len(m.sessions) {
+		m.cursor = max(len(m.sessions)-1, 0)
+	}
+	return m
 }
 
 func (m HistoryModel) Init() tea.Cmd {
@@ -51,31 +114,121 @@ func (m HistoryModel) Init() tea.Cmd {
 }
 
 func (m HistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "enter":
-			return m, func() tea.Msg { return HistoryToTownMsg{} }
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			if m.cursor < len(m.sessions)-1 {
-				m.cursor++
-			}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.detail != nil {
+		return m.updateDetail(key), nil
+	}
+	switch key.String() {
+	case "q", "esc":
+		return m, func() tea.Msg { return HistoryToTownMsg{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.sessions)-1 {
+			m.cursor++
+		}
+	case "left", "h":
+		if m.page > 0 {
+			m.page--
+			m.cursor = 0
+			m = m.load()
+		}
+	case "right", "l":
+		if m.page < m.pages()-1 {
+			m.page++
+			m.cursor = 0
+			m = m.load()
+		}
+	case "m":
+		m.modeIdx = (m.modeIdx + 1) % len(historyModes)
+		m.page, m.cursor = 0, 0
+		m = m.load()
+	case "d":
+		m.rangeIdx = (m.rangeIdx + 1) % len(historyRanges)
+		m.page, m.cursor = 0, 0
+		m = m.load()
+	case "enter":
+		if len(m.sessions) == 0 {
+			break
+		}
+		session := m.sessions[m.cursor]
+		items, err := db.ListSessionItems(context.Background(), session.ID)
+		if err != nil {
+			m.note = fmt.Sprintf(i18n.T("history_error"), err)
+			break
 		}
+		m.note = ""
+		m.detail = &historyDetail{session: session, items: items}
+	case "x":
+		m.note = exportCards()
 	}
 	return m, nil
 }
 
+func (m HistoryModel) updateDetail(key tea.KeyMsg) HistoryModel {
+	d := *m.detail
+	switch key.String() {
+	case "q", "esc", "enter", "backspace":
+		m.detail = nil
+		return m
+	case "up", "k":
+		if d.scroll > 0 {
+			d.scroll--
+		}
+	case "down", "j":
+		if d.scroll < len(d.items)-historyDetailItems {
+			d.scroll++
+		}
+	}
+	m.detail = &d
+	return m
+}
+
 func (m HistoryModel) View() string {
 	s := m.playerStats
 	header := components.Header(s, true, 0)
 
+	var body, footer string
+	if m.detail != nil {
+		body = m.viewDetail()
+		footer = components.Footer(i18n.T("footer_history_detail"), 0)
+	} else {
+		body = m.viewList(lipgloss.Width(header))
+		footer = components.Footer(i18n.T("footer_history"), 0)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		historyStyle.Render(body),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
+
+func (m HistoryModel) viewList(width int) string {
 	var b strings.Builder
 	b.WriteString(historyTitleStyle.Render(i18n.T("history_title") + "\n"))
-	b.WriteString(lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render("") + "\n")
+	b.WriteString(lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(width).Render("") + "\n")
+
+	mode := i18n.T("history_mode_all")
+	if historyModes[m.modeIdx] != "" {
+		mode = modeLabel(historyModes[m.modeIdx])
+	}
+	period := i18n.T("history_range_all")
+	switch days := historyRanges[m.rangeIdx]; days {
+	case 0:
+	case 1:
+		period = i18n.T("history_range_today")
+	default:
+		period = fmt.Sprintf(i18n.T("history_range_days"), days)
+	}
+	b.WriteString(historyHeaderStyle.Render(fmt.Sprintf(i18n.T("history_filter"), mode, period)) + "\n\n")
 
 	if len(m.sessions) == 0 {
 		b.WriteString(historyItemStyle.Render(i18n.T("history_no_sessions") + "\n"))
@@ -93,9 +246,15 @@ func (m HistoryModel) View() string {
 				cursor = ">"
 			}
 
-			date := session.EndedAt.Format("01/02 15:04")
+			date := session.EndedAt.Local().Format("01/02 15:04")
 			mode := session.Mode
-			score := fmt.Sprintf("%d/5", session.CorrectCount)
+			if session.Review {
+				mode += " ↺"
+			}
+			score := fmt.Sprintf("%d", session.CorrectCount)
+			if session.QuestionCount > 0 {
+				score = fmt.Sprintf("%d/%d", session.CorrectCount, session.QuestionCount)
+			}
 			exp := fmt.Sprintf("%+d", session.ExpGained)
 			gold := fmt.Sprintf("%+d", session.GoldDelta)
 			hp := fmt.Sprintf("%+d", session.HPDelta)
@@ -109,15 +268,76 @@ func (m HistoryModel) View() string {
 			rowCols := []string{cursor, date, mode, score, exp, gold, hp}
 			b.WriteString(components.RenderAlignedRow(rowCols, headerWidths) + "\n")
 		}
+		b.WriteString("\n" + historyMutedStyle.Render(fmt.Sprintf(i18n.T("history_page"), m.page+1, m.pages(), m.total)))
 	}
 
-	footer := components.Footer(i18n.T("footer_history"), 0)
+	if m.note != "" {
+		b.WriteString("\n" + historyNoteStyle.Render(m.note))
+	}
+	return b.String()
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		header,
-		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
-		historyStyle.Render(b.String()),
-		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
-		footer,
-	)
+func (m HistoryModel) viewDetail() string {
+	d := m.detail
+	s := d.session
+	var b strings.Builder
+	title := modeLabel(s.Mode)
+	if s.Review {
+		title += " " + i18n.T("history_review")
+	}
+	b.WriteString(historyTitleStyle.Render(fmt.Sprintf("%s — %s", title, s.EndedAt.Local().Format("2006/01/02 15:04"))) + "\n")
+	b.WriteString(historyHeaderStyle.Render(fmt.Sprintf(i18n.T("history_detail_summary"), s.CorrectCount, len(d.items), s.ExpGained, s.HPDelta, s.GoldDelta)) + "\n")
+
+	if len(d.items) == 0 {
+		b.WriteString("\n" + historyItemStyle.Render(i18n.T("history_detail_no_items")))
+		return b.String()
+	}
+	end := d.scroll + historyDetailItems
+	if end > len(d.items) {
+		end = len(d.items)
+	}
+	for _, it := range d.items[d.scroll:end] {
+		mark := historyRightStyle.Render("✓")
+		if !it.Correct {
+			mark = historyWrongStyle.Render("✗")
+		}
+		line := fmt.Sprintf("%d. %s %s", it.Seq+1, mark, it.Prompt)
+		if it.Outcome != "" {
+			line += historyMutedStyle.Render(" (" + it.Outcome + ")")
+		}
+		b.WriteString("\n" + line + "\n")
+		chosen := it.Chosen
+		if chosen == "" {
+			chosen = "—"
+		}
+		b.WriteString(historyItemStyle.Render(fmt.Sprintf(i18n.T("history_detail_answer"), chosen)) + "\n")
+		if !it.Correct && it.CorrectAnswer != "" {
+			b.WriteString(historyItemStyle.Render(fmt.Sprintf(i18n.T("history_detail_correct"), it.CorrectAnswer)) + "\n")
+		}
+		if it.Explanation != "" {
+			b.WriteString(historyItemStyle.Render(historyMutedStyle.Render(it.Explanation)) + "\n")
+		}
+	}
+	if len(d.items) > historyDetailItems {
+		b.WriteString("\n" + historyMutedStyle.Render(fmt.Sprintf(i18n.T("history_detail_range"), d.scroll+1, end, len(d.items))))
+	}
+	return b.String()
+}
+
+// exportCards writes the active profile's vocab and spelling cards as CSV
+// and Anki TSV into the exports directory and returns the note to show.
+func exportCards() string {
+	dir, err := config.ExportsPath()
+	if err != nil {
+		return fmt.Sprintf(i18n.T("history_cards_error"), err)
+	}
+	list, err := cards.Load(context.Background(), db.CurrentProfileID(), cards.Filter{})
+	if err != nil {
+		return fmt.Sprintf(i18n.T("history_cards_error"), err)
+	}
+	csvPath, ankiPath, err := cards.WriteFiles(dir, list, time.Now())
+	if err != nil {
+		return fmt.Sprintf(i18n.T("history_cards_error"), err)
+	}
+	return fmt.Sprintf(i18n.T("history_cards_exported"), len(list), csvPath, ankiPath)
 }
@@ -23,7 +23,7 @@ var (
 // ListeningModel is an interactive TUI for the Listening Cave.
 type ListeningModel struct {
 	playerStats  game.Stats
-	geminiClient *services.GeminiClient
+	provider     services.Provider
 	items        []services.ListeningItem
 	currentIndex int
 	selected     int
@@ -32,18 +32,22 @@ type ListeningModel struct {
 	showFeedback bool
 	quitting     bool
 	hpAnimator   HPAnimator
+	timer        QuestionTimer
+	gear         game.Effects // gear bonuses the session is settled with
 }
 
 // NewListeningModel creates a new ListeningModel.
-func NewListeningModel(stats game.Stats, gc *services.GeminiClient) ListeningModel {
+func NewListeningModel(stats game.Stats, p services.Provider) ListeningModel {
 	return ListeningModel{
 		playerStats:  stats,
-		geminiClient: gc,
+		provider:     p,
 		items:        []services.ListeningItem{},
 		currentIndex: 0,
 		selected:     0,
 		answers:      make([]game.ListeningAnswer, 0, 5),
 		hpAnimator:   NewHPAnimator(stats.HP),
+		timer:        NewQuestionTimer(),
+		gear:         game.EquipmentEffects(context.Background(), services.ModeListening),
 	}
 }
 
@@ -59,7 +63,7 @@ type ListeningQuestionMsg struct {
 
 func (m ListeningModel) fetchQuestionsCmd() tea.Cmd {
 	return func() tea.Msg {
-		payload, err := services.FetchAndValidate(context.Background(), services.ModeListening)
+		payload, err := services.FetchAndValidate(context.Background(), m.provider, services.ModeListening)
 		if err != nil {
 			return ListeningQuestionMsg{Err: err}
 		}
@@ -93,10 +97,19 @@ func (m ListeningModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.items = msg.Items
 		// speak first prompt
-		if len(m.items) > 0 {
-			_ = services.Speak(m.items[0].Prompt)
+		if len(m.items) == 0 {
+			return m, nil
 		}
-		return m, nil
+		_ = services.Speak(m.items[0].Prompt)
+		return m, m.timer.Start()
+
+	case questionTickMsg:
+		expired, cmd := m.timer.Tick(msg)
+		if expired && !m.showFeedback {
+			m.selected = -1
+			return m.submitAnswer(true)
+		}
+		return m, cmd
 
 	case hpTickMsg:
 		return m, m.hpAnimator.Tick(m.playerStats.HP)
@@ -139,41 +152,59 @@ func (m ListeningModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// speak next prompt
 				_ = services.Speak(m.items[m.currentIndex].Prompt)
-				return m, nil
+				return m, m.timer.Start()
 			}
-			// submit answer for current question
-			if m.currentIndex < len(m.items) {
+			return m.submitAnswer(false)
+		}
+	}
 
-				item := m.items[m.currentIndex]
-				isCorrect := m.selected == item.AnswerIndex
-				m.answers = append(m.answers, game.ListeningAnswer{Correct: isCorrect})
-				// Auto-finalize if we've answered all items
-				if len(m.answers) == len(m.items) {
-					return m.finalizeListeningSession()
-				}
+	return m, cmd
+}
 
-				if isCorrect {
-					m.feedback = i18n.T("correct_feedback")
-				} else {
-					m.feedback = fmt.Sprintf(i18n.T("incorrect_feedback"), item.Options[item.AnswerIndex])
-					prevHP := m.playerStats.HP
-					// Immediate HP update for UX
-					m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
-					M := game.AllowedMisses(len(m.items))
-					dmg := game.DamagePerMiss(m.playerStats.MaxHP, M)
-					m.playerStats = game.ApplyDamage(m.playerStats, dmg)
-					m.playerStats = game.ResetCombo(m.playerStats)
-					m.showFeedback = true
-					return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
-				}
-				m.showFeedback = true
-				return m, nil
+// submitAnswer grades the selected option for the current item. timedOut
+// marks an answer forced by the countdown running out.
+func (m ListeningModel) submitAnswer(timedOut bool) (ListeningModel, tea.Cmd) {
+	if m.currentIndex >= len(m.items) {
+		return m, nil
+	}
+	item := m.items[m.currentIndex]
+	isCorrect := m.selected == item.AnswerIndex
+	chosen := ""
+	if m.selected >= 0 && m.selected < len(item.Options) {
+		chosen = item.Options[m.selected]
+	}
+	m.answers = append(m.answers, game.ListeningAnswer{Correct: isCorrect, Item: game.QuestionLog{
+		Prompt:        item.Prompt,
+		Options:       item.Options,
+		Chosen:        chosen,
+		CorrectAnswer: item.Options[item.AnswerIndex],
+		Explanation:   item.Transcript,
+		Latency:       m.timer.Stop(),
+	}})
+	// Auto-finalize if we've answered all items
+	if len(m.answers) == len(m.items) {
+		return m.finalizeListeningSession()
+	}
 
-			}
+	if isCorrect {
+		m.feedback = i18n.T("correct_feedback")
+	} else {
+		m.feedback = fmt.Sprintf(i18n.T("incorrect_feedback"), item.Options[item.AnswerIndex])
+		if timedOut {
+			m.feedback = i18n.T("timer_time_up") + " " + m.feedback
 		}
+		prevHP := m.playerStats.HP
+		// Immediate HP update for UX
+		m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
+		M := game.AllowedMisses(len(m.items))
+		dmg := m.gear.Damage(game.DamagePerMiss(m.playerStats.MaxHP, M))
+		m.playerStats = game.ApplyDamage(m.playerStats, dmg)
+		m.playerStats = game.ResetCombo(m.playerStats)
+		m.showFeedback = true
+		return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
 	}
-
-	return m, cmd
+	m.showFeedback = true
+	return m, nil
 }
 
 func (m ListeningModel) finalizeListeningSession() (ListeningModel, tea.Cmd) {
@@ -229,6 +260,9 @@ func (m ListeningModel) View() string {
 
 	item := m.items[m.currentIndex]
 	qText := listeningTitleStyle.Render(fmt.Sprintf(i18n.T("listening_progress"), m.currentIndex+1, len(m.items))) + "\n\n"
+	if t := m.timer.View(); t != "" {
+		qText = t + "\n" + qText
+	}
 	qText += fmt.Sprintf("%s\n\n", i18n.T("press_r_replay"))
 
 	var opts []string
@@ -17,6 +17,8 @@ func modeLabel(mode string) string {
 		return i18n.T("town_menu_spelling_challenge")
 	case services.ModeListening:
 		return i18n.T("town_menu_listening_cave")
+	case "boss":
+		return i18n.T("town_menu_boss_lair")
 	default:
 		if mode == "" {
 			return ""
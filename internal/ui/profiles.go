@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/ui/components"
+)
+
+var (
+	profilesStyle      = lipgloss.NewStyle().Padding(1, 2)
+	profilesTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
+	profilesNoteStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted).Italic(true)
+)
+
+// ProfileSelectedMsg asks the RootModel to switch to the profile with ID.
+type ProfileSelectedMsg struct {
+	ID string
+}
+
+// ProfilesClosedMsg returns from the profile picker to the title menu.
+type ProfilesClosedMsg struct{}
+
+// ProfileDeletedMsg reports that the profile with ID and its data were removed.
+type ProfileDeletedMsg struct {
+	ID string
+}
+
+// ProfilesModel is the profile picker on the title screen: it lists the
+// saved profiles and creates, switches and deletes them.
+type ProfilesModel struct {
+	playerStats game.Stats
+	profiles    []db.ProfileRecord
+	activeID    string
+	cursor      int
+	creating    bool
+	character   CharacterModel
+	deleting    bool
+	note        string
+}
+
+// NewProfilesModel loads the saved profiles with the cursor on the active one.
+func NewProfilesModel(stats game.Stats) ProfilesModel {
+	m := ProfilesModel{playerStats: stats, activeID: db.CurrentProfileID()}
+	m = m.reload()
+	for i, p := range m.profiles {
+		if p.ID == m.activeID {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m ProfilesModel) reload() ProfilesModel {
+	profiles, err := db.ListProfiles(context.Background())
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("profiles_error"), err)
+	}
+	m.profiles = profiles
+	if m.cursor > len(m.profiles) {
+		m.cursor = len(m.profiles)
+	}
+	return m
+}
+
+func (m ProfilesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ProfilesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case CharacterCreatedMsg:
+		m.creating = false
+		id, err := game.CreateProfile(context.Background(), msg.Name, msg.Class)
+		if err != nil {
+			m.note = fmt.Sprintf(i18n.T("profiles_error"), err)
+			return m, nil
+		}
+		return m, func() tea.Msg { return ProfileSelectedMsg{ID: id} }
+	case CharacterCancelledMsg:
+		m.creating = false
+		return m, nil
+	}
+
+	if m.creating {
+		newCharacterModel, cmd := m.character.Update(msg)
+		m.character = newCharacterModel.(CharacterModel)
+		return m, cmd
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.deleting {
+		switch strings.ToLower(key.String()) {
+		case "y":
+			id := m.profiles[m.cursor].ID
+			m.deleting = false
+			if err := db.DeleteProfile(context.Background(), id); err != nil {
+				m.note = fmt.Sprintf(i18n.T("profiles_error"), err)
+				return m, nil
+			}
+			m.note = ""
+			m = m.reload()
+			return m, func() tea.Msg { return ProfileDeletedMsg{ID: id} }
+		case "n", "esc":
+			m.deleting = false
+			m.note = ""
+		}
+		return m, nil
+	}
+
+	switch key.String() {
+	case "esc", "q":
+		return m, func() tea.Msg { return ProfilesClosedMsg{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.profiles) {
+			m.cursor++
+		}
+	case "n":
+		return m.startCreating()
+	case "d":
+		if m.cursor >= len(m.profiles) {
+			return m, nil
+		}
+		if m.profiles[m.cursor].ID == m.activeID {
+			m.note = i18n.T("profiles_cannot_delete_active")
+			return m, nil
+		}
+		m.deleting = true
+		m.note = fmt.Sprintf(i18n.T("profiles_delete_confirm"), m.profiles[m.cursor].Name)
+	case "enter":
+		if m.cursor == len(m.profiles) {
+			return m.startCreating()
+		}
+		id := m.profiles[m.cursor].ID
+		return m, func() tea.Msg { return ProfileSelectedMsg{ID: id} }
+	}
+	return m, nil
+}
+
+func (m ProfilesModel) startCreating() (tea.Model, tea.Cmd) {
+	m.creating = true
+	m.note = ""
+	m.character = NewCharacterModel(m.playerStats, "")
+	return m, m.character.Init()
+}
+
+func (m ProfilesModel) View() string {
+	if m.creating {
+		return m.character.View()
+	}
+	header := components.Header(m.playerStats, true, 0)
+
+	var b strings.Builder
+	b.WriteString(profilesTitleStyle.Render(i18n.T("profiles_title")) + "\n\n")
+	labels := make([]string, 0, len(m.profiles)+1)
+	for _, p := range m.profiles {
+		label := fmt.Sprintf(i18n.T("profiles_line_format"), p.Name, p.Level, p.Class)
+		if p.ID == m.activeID {
+			label += " " + i18n.T("profiles_active")
+		}
+		labels = append(labels, label)
+	}
+	labels = append(labels, i18n.T("profiles_new"))
+	b.WriteString(components.Menu(labels, m.cursor, 1, 0))
+	if m.note != "" {
+		b.WriteString("\n" + profilesNoteStyle.Render(m.note))
+	}
+
+	footer := components.Footer(i18n.T("footer_profiles"), 0)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		profilesStyle.Render(b.String()),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
@@ -12,7 +12,8 @@ import (
 )
 
 var (
-	resultBoxStyle = lipgloss.NewStyle().Padding(1, 2)
+	resultBoxStyle   = lipgloss.NewStyle().Padding(1, 2)
+	resultToastStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(components.ColorAccent).Padding(0, 1)
 )
 
 // ResultModel shows the session summary before returning to town.
@@ -55,6 +56,15 @@ func (m ResultModel) View() string {
 		fmt.Sprintf(i18n.T("result_correct"), m.summary.Correct),
 	}
 
+	if boss, ok := game.BossByID(m.summary.BossID); ok {
+		name := i18n.T("boss_" + boss.ID)
+		if m.summary.BossDefeated {
+			lines = append(lines, lipgloss.NewStyle().Foreground(components.ColorAccent).Bold(true).Render(fmt.Sprintf(i18n.T("result_boss_defeated"), name, m.summary.BossVictories)))
+		} else {
+			lines = append(lines, fmt.Sprintf(i18n.T("result_boss_survived"), name, m.summary.BossHP, boss.HP))
+		}
+	}
+
 	if m.summary.HPDelta != 0 {
 		lines = append(lines, fmt.Sprintf(i18n.T("result_hp_delta"), m.summary.HPDelta))
 	}
@@ -64,6 +74,24 @@ func (m ResultModel) View() string {
 	if m.summary.DefenseDelta != 0 {
 		lines = append(lines, fmt.Sprintf(i18n.T("result_defense_delta"), m.summary.DefenseDelta))
 	}
+	if m.summary.FastAnswers > 0 {
+		lines = append(lines, fmt.Sprintf(i18n.T("result_fast_answers"), m.summary.FastAnswers))
+	}
+	if m.summary.PotionsUsed > 0 {
+		lines = append(lines, fmt.Sprintf(i18n.T("result_potions_used"), m.summary.PotionsUsed))
+	}
+
+	if streak := m.summary.Streak; streak.Broken() {
+		lines = append(lines, lipgloss.NewStyle().Foreground(components.ColorDanger).Render(fmt.Sprintf(i18n.T("result_streak_broken"), streak.Before, streak.After)))
+	} else if streak.After > streak.Before {
+		lines = append(lines, fmt.Sprintf(i18n.T("result_streak_up"), streak.Before, streak.After))
+	}
+	if m.summary.Streak.FreezesUsed > 0 {
+		lines = append(lines, fmt.Sprintf(i18n.T("result_streak_freeze_used"), m.summary.Streak.FreezesUsed))
+	}
+	if m.summary.Streak.FreezeEarned {
+		lines = append(lines, lipgloss.NewStyle().Foreground(components.ColorPrimary).Render(i18n.T("result_streak_freeze_earned")))
+	}
 
 	if strings.TrimSpace(m.summary.Note) != "" {
 		lines = append(lines, fmt.Sprintf(i18n.T("result_note"), m.summary.Note))
@@ -76,6 +104,14 @@ func (m ResultModel) View() string {
 		lines = append(lines, lipgloss.NewStyle().Foreground(components.ColorDanger).Render(i18n.T("result_fainted")))
 	}
 
+	if len(m.summary.Unlocked) > 0 {
+		toast := make([]string, 0, len(m.summary.Unlocked))
+		for _, a := range m.summary.Unlocked {
+			toast = append(toast, fmt.Sprintf(i18n.T("result_achievement_unlocked"), i18n.T("achievement_"+a.ID)))
+		}
+		lines = append(lines, "", resultToastStyle.Render(strings.Join(toast, "\n")))
+	}
+
 	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
 
 	width := lipgloss.Width(header) - resultBoxStyle.GetHorizontalPadding()
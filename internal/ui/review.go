@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/services"
+	"tui-english-quest/internal/ui/components"
+)
+
+var (
+	reviewStyle      = lipgloss.NewStyle().Padding(1, 2)
+	reviewTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
+	reviewNoteStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted).Italic(true)
+)
+
+// TownToReviewMsg signals to the RootModel to open the Review Shrine.
+type TownToReviewMsg struct{}
+
+// ReviewToTownMsg signals to the RootModel to return to Town from the shrine.
+type ReviewToTownMsg struct{}
+
+// ReviewStartMsg asks the RootModel to start a review session for Mode.
+type ReviewStartMsg struct {
+	Mode string
+}
+
+// ReviewModel is the Review Shrine: it lists due spaced-repetition items per
+// mode and starts a review session through the regular mode screens.
+type ReviewModel struct {
+	playerStats game.Stats
+	counts      map[string]int
+	cursor      int
+	note        string
+}
+
+// NewReviewModel creates a ReviewModel with the current due counts.
+func NewReviewModel(stats game.Stats) ReviewModel {
+	counts, err := game.DueReviewCounts(context.Background())
+	note := ""
+	if err != nil {
+		log.Printf("failed to count due reviews: %v", err)
+		counts = map[string]int{}
+		note = fmt.Sprintf(i18n.T("review_error"), err)
+	}
+	return ReviewModel{playerStats: stats, counts: counts, note: note}
+}
+
+func (m ReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc":
+			return m, func() tea.Msg { return ReviewToTownMsg{} }
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(game.ReviewModes)-1 {
+				m.cursor++
+			}
+		case "enter":
+			mode := game.ReviewModes[m.cursor]
+			if m.counts[mode] == 0 {
+				m.note = i18n.T("review_nothing_due")
+				return m, nil
+			}
+			return m, func() tea.Msg { return ReviewStartMsg{Mode: mode} }
+		}
+	}
+	return m, nil
+}
+
+func (m ReviewModel) View() string {
+	header := components.Header(m.playerStats, true, 0)
+
+	var b strings.Builder
+	b.WriteString(reviewTitleStyle.Render(i18n.T("review_title")) + "\n\n")
+	b.WriteString(i18n.T("review_prompt") + "\n\n")
+	labels := make([]string, len(game.ReviewModes))
+	for i, mode := range game.ReviewModes {
+		labels[i] = fmt.Sprintf(i18n.T("review_due_format"), modeLabel(mode), m.counts[mode])
+	}
+	b.WriteString(components.Menu(labels, m.cursor, 2, 0))
+	if m.note != "" {
+		b.WriteString("\n" + reviewNoteStyle.Render(m.note))
+	}
+
+	footer := components.Footer(i18n.T("footer_review"), 0)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		reviewStyle.Render(b.String()),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
+
+// answerIndex locates the logged correct answer among the logged options.
+func answerIndex(q game.QuestionLog) int {
+	for i, opt := range q.Options {
+		if opt == q.CorrectAnswer {
+			return i
+		}
+	}
+	return -1
+}
+
+// vocabReviewQuestions rebuilds battle questions from due review items,
+// skipping items whose options no longer contain the answer.
+func vocabReviewQuestions(items []game.ReviewItem) []services.VocabQuestion {
+	qs := make([]services.VocabQuestion, 0, len(items))
+	for _, it := range items {
+		idx := answerIndex(it.Item)
+		if idx < 0 {
+			continue
+		}
+		qs = append(qs, services.VocabQuestion{
+			EnemyName:   i18n.T("review_enemy_name"),
+			Word:        it.Item.Prompt,
+			Options:     it.Item.Options,
+			AnswerIndex: idx,
+			Explanation: it.Item.Explanation,
+		})
+	}
+	return qs
+}
+
+// grammarReviewTraps rebuilds dungeon traps from due review items.
+func grammarReviewTraps(items []game.ReviewItem) []services.GrammarTrap {
+	traps := make([]services.GrammarTrap, 0, len(items))
+	for _, it := range items {
+		idx := answerIndex(it.Item)
+		if idx < 0 {
+			continue
+		}
+		traps = append(traps, services.GrammarTrap{
+			TrapName:    i18n.T("review_enemy_name"),
+			Question:    it.Item.Prompt,
+			Options:     it.Item.Options,
+			AnswerIndex: idx,
+			Explanation: it.Item.Explanation,
+		})
+	}
+	return traps
+}
+
+// spellingReviewPrompts rebuilds spelling prompts from due review items.
+func spellingReviewPrompts(items []game.ReviewItem) []services.SpellingPrompt {
+	prompts := make([]services.SpellingPrompt, 0, len(items))
+	for _, it := range items {
+		if it.Item.CorrectAnswer == "" {
+			continue
+		}
+		prompts = append(prompts, services.SpellingPrompt{
+			JAHint:          it.Item.Prompt,
+			CorrectSpelling: it.Item.CorrectAnswer,
+			Explanation:     it.Item.Explanation,
+		})
+	}
+	return prompts
+}
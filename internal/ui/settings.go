@@ -34,6 +34,8 @@ type SettingsModel struct {
 	// Session settings
 	questionsPerSession int
 	questionsOptions    []int
+	timeLimit           int // seconds per question; 0 is off
+	timeLimitOptions    []int
 }
 
 // NewSettingsModel creates a new SettingsModel.
@@ -59,7 +61,7 @@ func NewSettingsModel(stats game.Stats) SettingsModel {
 	}
 
 	// build initial menu including a QuestionsPerSession display
-	menu := []string{i18n.T("settings_menu_api"), fmt.Sprintf(i18n.T("settings_menu_lang_current"), strings.ToUpper(cfg.LangPref)), fmt.Sprintf(i18n.T("settings_menu_questions_current"), qps), i18n.T("settings_save")}
+	menu := []string{i18n.T("settings_menu_api"), fmt.Sprintf(i18n.T("settings_menu_lang_current"), strings.ToUpper(cfg.LangPref)), fmt.Sprintf(i18n.T("settings_menu_questions_current"), qps), timeLimitLabel(cfg.QuestionTimeLimit), i18n.T("settings_save")}
 
 	return SettingsModel{
 		playerStats:         stats,
@@ -73,6 +75,8 @@ func NewSettingsModel(stats game.Stats) SettingsModel {
 		langPref:            cfg.LangPref,
 		questionsPerSession: qps,
 		questionsOptions:    questionsOpts,
+		timeLimit:           cfg.QuestionTimeLimit,
+		timeLimitOptions:    []int{0, 15, 20, 30, 60},
 	}
 }
 
@@ -101,7 +105,9 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case i18n.T("confirm_save_opt1"):
 					// Save logic: write API key and config
 					apiKey := m.apiKeyInput.Value()
-					cfg := config.Config{LangPref: m.langPref, ApiKey: apiKey}
+					cfg, _ := config.LoadConfig()
+					cfg.LangPref = m.langPref
+					cfg.ApiKey = apiKey
 					if err := config.SaveConfig(cfg); err != nil {
 						// TODO: show error
 					}
@@ -182,9 +188,27 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.menu[2] = fmt.Sprintf(i18n.T("settings_menu_questions_current"), m.questionsPerSession)
 
 			case 3:
+				// Cycle the per-question time limit
+				curIdx := 0
+				for i, v := range m.timeLimitOptions {
+					if v == m.timeLimit {
+						curIdx = i
+						break
+					}
+				}
+				curIdx = (curIdx + 1) % len(m.timeLimitOptions)
+				m.timeLimit = m.timeLimitOptions[curIdx]
+				m.menu[3] = timeLimitLabel(m.timeLimit)
+
+			case 4:
 				// Save and exit
 				apiKey := m.apiKeyInput.Value()
-				cfg := config.Config{LangPref: m.langPref, ApiKey: apiKey, QuestionsPerSession: m.questionsPerSession}
+				// Start from the saved config so fields not shown here (profile, backend) survive.
+				cfg, _ := config.LoadConfig()
+				cfg.LangPref = m.langPref
+				cfg.ApiKey = apiKey
+				cfg.QuestionsPerSession = m.questionsPerSession
+				cfg.QuestionTimeLimit = m.timeLimit
 				if err := config.SaveConfig(cfg); err != nil {
 					// handle error
 				}
@@ -257,3 +281,11 @@ func (m SettingsModel) View() string {
 		footer,
 	)
 }
+
+// timeLimitLabel renders the time limit menu entry.
+func timeLimitLabel(seconds int) string {
+	if seconds <= 0 {
+		return i18n.T("settings_menu_timer_off")
+	}
+	return fmt.Sprintf(i18n.T("settings_menu_timer_current"), seconds)
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/db"
+	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
+	"tui-english-quest/internal/ui/components"
+)
+
+var (
+	shopStyle      = lipgloss.NewStyle().Padding(1, 2)
+	shopTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
+	shopDescStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted)
+	shopNoteStyle  = lipgloss.NewStyle().Foreground(components.ColorMuted).Italic(true)
+)
+
+// TownToShopMsg signals to the RootModel to open the Shop screen.
+type TownToShopMsg struct{}
+
+// ShopToTownMsg signals to the RootModel to return to Town.
+type ShopToTownMsg struct{}
+
+// ShopModel lists the shop's offers and buys the selected one with Gold.
+type ShopModel struct {
+	playerStats game.Stats
+	offers      []game.Offer
+	cursor      int
+	note        string
+}
+
+// NewShopModel loads the shop's offers for the active profile.
+func NewShopModel(stats game.Stats) ShopModel {
+	return ShopModel{playerStats: stats}.reload()
+}
+
+func (m ShopModel) reload() ShopModel {
+	offers, err := game.ShopOffers(context.Background())
+	if err != nil {
+		m.note = fmt.Sprintf(i18n.T("shop_error"), err)
+	}
+	m.offers = offers
+	if m.cursor >= len(m.offers) {
+		m.cursor = max(len(m.offers)-1, 0)
+	}
+	return m
+}
+
+func (m ShopModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ShopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "q", "esc":
+		return m, func() tea.Msg { return ShopToTownMsg{} }
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.offers)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if len(m.offers) == 0 {
+			return m, nil
+		}
+		offer := m.offers[m.cursor]
+		if offer.SoldOut() {
+			m.note = i18n.T("shop_sold_out")
+			return m, nil
+		}
+		stats, err := game.Buy(context.Background(), m.playerStats, offer)
+		switch {
+		case errors.Is(err, db.ErrNotEnoughGold):
+			m.note = i18n.T("shop_not_enough_gold")
+		case errors.Is(err, db.ErrAlreadyOwned), errors.Is(err, db.ErrItemLimit):
+			m.note = i18n.T("shop_sold_out")
+		case err != nil:
+			m.note = fmt.Sprintf(i18n.T("shop_error"), err)
+		default:
+			m.playerStats = stats
+			m = m.reload()
+			m.note = fmt.Sprintf(i18n.T("shop_bought"), offer.Name, offer.Price)
+		}
+	}
+	return m, nil
+}
+
+func (m ShopModel) View() string {
+	header := components.Header(m.playerStats, true, 0)
+
+	var b strings.Builder
+	b.WriteString(shopTitleStyle.Render(i18n.T("shop_title")) + "\n")
+	b.WriteString(fmt.Sprintf(i18n.T("shop_gold"), m.playerStats.Gold) + "\n\n")
+	labels := make([]string, len(m.offers))
+	for i, o := range m.offers {
+		labels[i] = shopLabel(o)
+	}
+	b.WriteString(components.Menu(labels, m.cursor, 2, 0))
+	if m.cursor < len(m.offers) {
+		b.WriteString("\n" + shopDescStyle.Render(shopDescription(m.offers[m.cursor])) + "\n")
+	}
+	if m.note != "" {
+		b.WriteString("\n" + shopNoteStyle.Render(m.note))
+	}
+
+	footer := components.Footer(i18n.T("footer_shop"), 0)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).Width(lipgloss.Width(header)).Render(""),
+		shopStyle.Render(b.String()),
+		lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false).Width(lipgloss.Width(header)).Render(""),
+		footer,
+	)
+}
+
+// shopLabel renders an offer with its price and how many the player holds,
+// e.g. "HP Potion  40 G  (x2/5)".
+func shopLabel(o game.Offer) string {
+	held := ""
+	switch {
+	case o.Kind == game.OfferEquipment && o.Owned > 0:
+		held = i18n.T("shop_owned")
+	case o.Kind == game.OfferItem && o.Item.Limit > 0:
+		held = fmt.Sprintf("(x%d/%d)", o.Owned, o.Item.Limit)
+	case o.Kind == game.OfferItem:
+		held = fmt.Sprintf("(x%d)", o.Owned)
+	}
+	return fmt.Sprintf("%-18s %5d G  %s", o.Name, o.Price, held)
+}
+
+// shopDescription explains what the selected offer does.
+func shopDescription(o game.Offer) string {
+	if o.Kind == game.OfferEquipment {
+		return fmt.Sprintf("%s: %s", i18n.T("equipment_slot_"+o.Equipment.Slot), equipmentLabel(o.Equipment))
+	}
+	return o.Item.Description
+}
@@ -32,7 +32,7 @@ var (
 // SpellingModel is the TUI for the Spelling Challenge.
 type SpellingModel struct {
 	playerStats      game.Stats
-	geminiClient     *services.GeminiClient
+	provider         services.Provider
 	prompts          []services.SpellingPrompt
 	currentQuestion  int
 	answerInput      textinput.Model
@@ -43,7 +43,10 @@ type SpellingModel struct {
 	feedback         string
 	quitting         bool
 	hpAnimator       HPAnimator
-	answers          []game.SpellingOutcome
+	answers          []game.SpellingAnswer
+	review           []services.SpellingPrompt // Due review items served instead of fetching
+	timer            QuestionTimer
+	gear             game.Effects // gear bonuses the session is settled with
 }
 
 // SpellingQuestionMsg is sent when questions are fetched.
@@ -53,7 +56,7 @@ type SpellingQuestionMsg struct {
 }
 
 // NewSpellingModel creates a new SpellingModel.
-func NewSpellingModel(stats game.Stats, gc *services.GeminiClient) SpellingModel {
+func NewSpellingModel(stats game.Stats, p services.Provider) SpellingModel {
 	ti := textinput.New()
 	ti.Placeholder = i18n.T("spelling_placeholder")
 	ti.Focus()
@@ -62,7 +65,7 @@ func NewSpellingModel(stats game.Stats, gc *services.GeminiClient) SpellingModel
 
 	return SpellingModel{
 		playerStats:      stats,
-		geminiClient:     gc,
+		provider:         p,
 		prompts:          []services.SpellingPrompt{},
 		currentQuestion:  0,
 		answerInput:      ti,
@@ -73,15 +76,29 @@ func NewSpellingModel(stats game.Stats, gc *services.GeminiClient) SpellingModel
 		feedback:         "",
 		quitting:         false,
 		hpAnimator:       NewHPAnimator(stats.HP),
-		answers:          make([]game.SpellingOutcome, 0, 5),
+		answers:          make([]game.SpellingAnswer, 0, 5),
+		timer:            NewQuestionTimer(),
+		gear:             game.EquipmentEffects(context.Background(), services.ModeSpelling),
 	}
 }
 
+// NewSpellingReviewModel creates a SpellingModel that serves the given review
+// prompts instead of fetching a fresh set.
+func NewSpellingReviewModel(stats game.Stats, prompts []services.SpellingPrompt) SpellingModel {
+	m := NewSpellingModel(stats, nil)
+	m.review = prompts
+	return m
+}
+
 func (m SpellingModel) Init() tea.Cmd { return tea.Batch(textinput.Blink, m.fetchQuestionsCmd()) }
 
 func (m SpellingModel) fetchQuestionsCmd() tea.Cmd {
+	if m.review != nil {
+		ps := m.review
+		return func() tea.Msg { return SpellingQuestionMsg{Prompts: ps} }
+	}
 	return func() tea.Msg {
-		payload, err := services.FetchAndValidate(context.Background(), services.ModeSpelling)
+		payload, err := services.FetchAndValidate(context.Background(), m.provider, services.ModeSpelling)
 		if err != nil {
 			return SpellingQuestionMsg{Err: err}
 		}
@@ -116,7 +133,17 @@ func (m SpellingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.prompts = msg.Prompts
-		return m, nil
+		if len(m.prompts) == 0 {
+			return m, nil
+		}
+		return m, m.timer.Start()
+
+	case questionTickMsg:
+		expired, cmd := m.timer.Tick(msg)
+		if expired && !m.showFeedback {
+			return m.submit("", true)
+		}
+		return m, cmd
 
 	case hpTickMsg:
 		return m, m.hpAnimator.Tick(m.playerStats.HP)
@@ -148,7 +175,7 @@ func (m SpellingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m.finalizeSpellingSession()
 				}
 				m = m.refreshMCOptionsForCurrentPrompt()
-				return m, nil
+				return m, m.timer.Start()
 			}
 
 			// Process answer for fill-in
@@ -156,37 +183,7 @@ func (m SpellingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// ignore enter for MC
 				return m, nil
 			}
-			current := m.prompts[m.currentQuestion]
-			user := strings.TrimSpace(m.answerInput.Value())
-			if strings.EqualFold(user, current.CorrectSpelling) {
-				m.feedback = i18n.T("correct_feedback")
-				m.isCorrect = true
-				m.answers = append(m.answers, game.SpellingPerfect)
-			} else if isNear(user, current.CorrectSpelling) {
-				m.feedback = fmt.Sprintf(i18n.T("spelling_almost_correct"), current.CorrectSpelling)
-				m.isCorrect = false
-				m.answers = append(m.answers, game.SpellingNear)
-			} else {
-				m.feedback = fmt.Sprintf(i18n.T("spelling_incorrect"), current.CorrectSpelling)
-				m.isCorrect = false
-				m.answers = append(m.answers, game.SpellingFail)
-				prevHP := m.playerStats.HP
-				// Immediate HP update for UX
-				m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
-				M := game.AllowedMisses(len(m.prompts))
-				dmg := game.DamagePerMiss(m.playerStats.MaxHP, M)
-				m.playerStats = game.ApplyDamage(m.playerStats, dmg)
-				m.playerStats = game.ResetCombo(m.playerStats)
-				m.showFeedback = true
-				return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
-			}
-			// Auto-finalize when we've answered all prompts
-			if len(m.answers) == len(m.prompts) {
-				return m.finalizeSpellingSession()
-			}
-
-			m.showFeedback = true
-			return m, nil
+			return m.submit(strings.TrimSpace(m.answerInput.Value()), false)
 
 		case "1", "2", "3", "4":
 			// Handle MC selection
@@ -197,32 +194,7 @@ func (m SpellingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if idx < 0 || idx >= len(m.mcOptions) {
 				return m, nil
 			}
-			selected := m.mcOptions[idx]
-			current := m.prompts[m.currentQuestion]
-			if strings.EqualFold(selected, current.CorrectSpelling) {
-				m.feedback = i18n.T("correct_feedback")
-				m.isCorrect = true
-				m.answers = append(m.answers, game.SpellingPerfect)
-			} else if isNear(selected, current.CorrectSpelling) {
-				m.feedback = fmt.Sprintf(i18n.T("spelling_almost_correct"), current.CorrectSpelling)
-				m.isCorrect = false
-				m.answers = append(m.answers, game.SpellingNear)
-			} else {
-				m.feedback = fmt.Sprintf(i18n.T("spelling_incorrect"), current.CorrectSpelling)
-				m.isCorrect = false
-				m.answers = append(m.answers, game.SpellingFail)
-				prevHP := m.playerStats.HP
-				// Immediate HP update for UX
-				m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
-				M := game.AllowedMisses(len(m.prompts))
-				dmg := game.DamagePerMiss(m.playerStats.MaxHP, M)
-				m.playerStats = game.ApplyDamage(m.playerStats, dmg)
-				m.playerStats = game.ResetCombo(m.playerStats)
-				m.showFeedback = true
-				return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
-			}
-			m.showFeedback = true
-			return m, nil
+			return m.submit(m.mcOptions[idx], false)
 		}
 	}
 
@@ -234,8 +206,69 @@ func (m SpellingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submit grades chosen for the current prompt, either typed or picked from
+// the multiple-choice options. timedOut marks an answer forced by the
+// countdown running out.
+func (m SpellingModel) submit(chosen string, timedOut bool) (SpellingModel, tea.Cmd) {
+	current := m.prompts[m.currentQuestion]
+	if strings.EqualFold(chosen, current.CorrectSpelling) {
+		m.feedback = i18n.T("correct_feedback")
+		m.isCorrect = true
+		m = m.recordAnswer(game.SpellingPerfect, chosen)
+	} else if isNear(chosen, current.CorrectSpelling) {
+		m.feedback = fmt.Sprintf(i18n.T("spelling_almost_correct"), current.CorrectSpelling)
+		m.isCorrect = false
+		m = m.recordAnswer(game.SpellingNear, chosen)
+	} else {
+		m.feedback = fmt.Sprintf(i18n.T("spelling_incorrect"), current.CorrectSpelling)
+		if timedOut {
+			m.feedback = i18n.T("timer_time_up") + " " + m.feedback
+		}
+		m.isCorrect = false
+		m = m.recordAnswer(game.SpellingFail, chosen)
+		prevHP := m.playerStats.HP
+		// Immediate HP update for UX
+		m.playerStats.MaxHP = game.MaxHPForLevel(m.playerStats.Level)
+		M := game.AllowedMisses(len(m.prompts))
+		dmg := m.gear.Damage(game.DamagePerMiss(m.playerStats.MaxHP, M))
+		m.playerStats = game.ApplyDamage(m.playerStats, dmg)
+		m.playerStats = game.ResetCombo(m.playerStats)
+		m.showFeedback = true
+		return m, m.hpAnimator.StartAnimation(prevHP, m.playerStats.HP)
+	}
+	// Auto-finalize when we've answered all prompts
+	if len(m.answers) == len(m.prompts) {
+		return m.finalizeSpellingSession()
+	}
+
+	m.showFeedback = true
+	return m, nil
+}
+
+// recordAnswer appends the graded outcome for the current prompt.
+func (m SpellingModel) recordAnswer(outcome game.SpellingOutcome, chosen string) SpellingModel {
+	current := m.prompts[m.currentQuestion]
+	var options []string
+	if m.isMultipleChoice {
+		options = append([]string(nil), m.mcOptions...)
+	}
+	m.answers = append(m.answers, game.SpellingAnswer{Outcome: outcome, Item: game.QuestionLog{
+		Prompt:        current.JAHint,
+		Options:       options,
+		Chosen:        chosen,
+		CorrectAnswer: current.CorrectSpelling,
+		Explanation:   current.Explanation,
+		Latency:       m.timer.Stop(),
+	}})
+	return m
+}
+
 func (m SpellingModel) finalizeSpellingSession() (SpellingModel, tea.Cmd) {
-	updatedStats, summary, err := game.RunSpellingSession(context.Background(), m.playerStats, m.answers)
+	ctx := context.Background()
+	if m.review != nil {
+		ctx = game.WithReview(ctx)
+	}
+	updatedStats, summary, err := game.RunSpellingSession(ctx, m.playerStats, m.answers)
 	if err != nil {
 		m.feedback = fmt.Sprintf("Session error: %v", err)
 		m.showFeedback = true
@@ -282,6 +315,9 @@ func (m SpellingModel) View() string {
 
 		current := m.prompts[m.currentQuestion]
 		questionText := spellingQuestionStyle.Render(fmt.Sprintf(i18n.T("spelling_question_progress"), m.currentQuestion+1, len(m.prompts), current.JAHint))
+		if t := m.timer.View(); t != "" {
+			questionText = lipgloss.JoinVertical(lipgloss.Left, t, questionText)
+		}
 
 		// Calculate content width based on header width
 		contentWidth := lipgloss.Width(header) - spellingStyle.GetHorizontalPadding()
@@ -1,30 +1,52 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"tui-english-quest/internal/game"
+	"tui-english-quest/internal/i18n"
 	"tui-english-quest/internal/ui/components"
 )
 
 var (
-	statusStyle      = lipgloss.NewStyle().Padding(1, 2)
-	statusTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
-	statusItemStyle  = lipgloss.NewStyle().PaddingLeft(2)
+	statusStyle       = lipgloss.NewStyle().Padding(1, 2)
+	statusTitleStyle  = lipgloss.NewStyle().Bold(true).Foreground(components.ColorPrimary)
+	statusItemStyle   = lipgloss.NewStyle().PaddingLeft(2)
+	statusLockedStyle = lipgloss.NewStyle().Foreground(components.ColorMuted)
 )
 
 // StatusModel displays the player's current status and growth.
 type StatusModel struct {
-	playerStats game.Stats
+	playerStats  game.Stats
+	freezes      int
+	potions      int
+	achievements []game.AchievementStatus
 }
 
 // NewStatusModel creates a new StatusModel.
 func NewStatusModel(stats game.Stats) StatusModel {
+	freezes, err := game.StreakFreezes(context.Background())
+	if err != nil {
+		log.Printf("failed to load streak freezes: %v", err)
+	}
+	potions, err := game.HPPotions(context.Background())
+	if err != nil {
+		log.Printf("failed to load potions: %v", err)
+	}
+	achievements, err := game.AchievementProgress(context.Background())
+	if err != nil {
+		log.Printf("failed to load achievements: %v", err)
+	}
 	return StatusModel{
-		playerStats: stats,
+		playerStats:  stats,
+		freezes:      freezes,
+		potions:      potions,
+		achievements: achievements,
 	}
 }
 
@@ -59,10 +81,22 @@ func (m StatusModel) View() string {
 	lines += components.RenderKeyValue("Level:", fmt.Sprintf("%d", s.Level), labelWidth) + "\n"
 	lines += components.RenderKeyValue("Experience:", fmt.Sprintf("%d / %d", s.Exp, s.Next), labelWidth) + "\n"
 	lines += components.RenderKeyValue("HP:", fmt.Sprintf("%d / %d", s.HP, s.MaxHP), labelWidth) + "\n"
+	lines += components.RenderKeyValue("Streak:", fmt.Sprintf("%d days (%d/%d freezes)", s.Streak, m.freezes, game.MaxStreakFreezes), labelWidth) + "\n"
+	lines += components.RenderKeyValue("Gold:", fmt.Sprintf("%d", s.Gold), labelWidth) + "\n"
+	lines += components.RenderKeyValue("HP Potions:", fmt.Sprintf("%d", m.potions), labelWidth) + "\n"
 
-	// Badges or achievements (placeholder)
-	lines += "\nAchievements:\n\n"
-	achievements := []string{"First Victory", "Combo Master"}
+	unlocked := 0
+	achievements := make([]string, len(m.achievements))
+	for i, a := range m.achievements {
+		name := i18n.T("achievement_" + a.ID)
+		if a.Unlocked() {
+			unlocked++
+			achievements[i] = fmt.Sprintf("🏆 %s (%s)", name, a.UnlockedAt.Local().Format("2006-01-02"))
+		} else {
+			achievements[i] = statusLockedStyle.Render(fmt.Sprintf("🔒 %s: %s", name, i18n.T("achievement_"+a.ID+"_desc")))
+		}
+	}
+	lines += fmt.Sprintf("\nAchievements (%d/%d):\n\n", unlocked, len(m.achievements))
 	lines += components.RenderBulletList(achievements, 2)
 
 	b.WriteString(lines)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,7 +20,7 @@ import (
 // TavernModel represents the conversation tavern UI.
 type TavernModel struct {
 	playerStats      game.Stats
-	geminiClient     *services.GeminiClient
+	provider         services.Provider
 	npcName          string
 	npcOpening       string
 	turns            []services.TavernTurn
@@ -28,6 +29,11 @@ type TavernModel struct {
 	playerUtterances []string
 	evaluations      []services.TavernEvaluation
 
+	// live is set while the backend answers each utterance in turn; the NPC's
+	// reply then replaces the scripted line of the next turn.
+	live       bool
+	npcClosing string
+
 	currentTurn int
 	input       textinput.Model
 
@@ -48,12 +54,19 @@ type TavernQuestionMsg struct {
 	Err              error
 }
 
+// TavernReplyMsg carries the live NPC reply to the utterance of Turn (0-based).
+type TavernReplyMsg struct {
+	Turn  int
+	Reply services.TavernReply
+	Err   error
+}
+
 type TavernEvalMsg struct {
 	Evaluations []services.TavernEvaluation
 	Err         error
 }
 
-func NewTavernModel(stats game.Stats, gc *services.GeminiClient, langPref string) TavernModel {
+func NewTavernModel(stats game.Stats, p services.Provider, langPref string) TavernModel {
 	ti := textinput.New()
 	ti.Placeholder = i18n.T("tavern_placeholder")
 
@@ -63,7 +76,7 @@ func NewTavernModel(stats game.Stats, gc *services.GeminiClient, langPref string
 
 	return TavernModel{
 		playerStats:      stats,
-		geminiClient:     gc,
+		provider:         p,
 		turns:            []services.TavernTurn{},
 		evaluationRubric: []string{},
 		playerUtterances: make([]string, 0, 5),
@@ -80,7 +93,7 @@ func (m TavernModel) Init() tea.Cmd {
 
 func (m TavernModel) fetchTavernCmd() tea.Cmd {
 	return func() tea.Msg {
-		payload, err := services.FetchAndValidate(context.Background(), services.ModeTavern)
+		payload, err := services.FetchAndValidate(context.Background(), m.provider, services.ModeTavern)
 		if err != nil {
 			return TavernQuestionMsg{Err: err}
 		}
@@ -115,7 +128,10 @@ func (m TavernModel) fetchTavernCmd() tea.Cmd {
 
 func (m TavernModel) batchEvaluateCmd() tea.Cmd {
 	return func() tea.Msg {
-		evals, err := m.geminiClient.BatchEvaluateTavern(context.Background(), m.evaluationRubric, m.npcOpening, m.turns, m.playerUtterances, m.langPref)
+		if m.provider == nil {
+			return TavernEvalMsg{Err: services.ErrNoProvider}
+		}
+		evals, err := m.provider.BatchEvaluateTavern(context.Background(), m.evaluationRubric, m.npcOpening, m.turns, m.playerUtterances, m.langPref)
 		if err != nil {
 			return TavernEvalMsg{Err: err}
 		}
@@ -123,6 +139,59 @@ func (m TavernModel) batchEvaluateCmd() tea.Cmd {
 	}
 }
 
+// transcript returns the conversation up to the NPC line the player is answering.
+func (m TavernModel) transcript() []services.TavernLine {
+	lines := make([]services.TavernLine, 0, 2*m.currentTurn+1)
+	for i := 0; i < m.currentTurn && i < len(m.playerUtterances); i++ {
+		lines = append(lines,
+			services.TavernLine{Speaker: "npc", Text: m.turns[i].NPCReply},
+			services.TavernLine{Speaker: "player", Text: m.playerUtterances[i]},
+		)
+	}
+	return append(lines, services.TavernLine{Speaker: "npc", Text: m.turns[m.currentTurn].NPCReply})
+}
+
+func (m TavernModel) liveReplyCmd(utterance string) tea.Cmd {
+	req := services.TavernReplyRequest{
+		NPCName:    m.npcName,
+		NPCOpening: m.npcOpening,
+		Rubric:     m.evaluationRubric,
+		Transcript: m.transcript(),
+		Utterance:  utterance,
+		Turn:       m.currentTurn + 1,
+		TotalTurns: len(m.turns),
+		Lang:       m.langPref,
+	}
+	turn := m.currentTurn
+	p := m.provider
+	return func() tea.Msg {
+		reply, err := services.RequestTavernReply(context.Background(), p, req)
+		return TavernReplyMsg{Turn: turn, Reply: reply, Err: err}
+	}
+}
+
+// settle pays out the session once every turn has an evaluation.
+func (m TavernModel) settle() TavernModel {
+	answers := make([]game.TavernAnswer, len(m.evaluations))
+	for i, e := range m.evaluations {
+		answers[i] = game.TavernAnswer{Outcome: game.ParseTavernOutcome(e.Outcome)}
+		if i < len(m.turns) && i < len(m.playerUtterances) {
+			answers[i].Item = game.QuestionLog{
+				Prompt:      m.turns[i].NPCReply,
+				Chosen:      m.playerUtterances[i],
+				Explanation: e.Reason,
+			}
+		}
+	}
+
+	updatedStats, summary, _ := game.RunTavernSession(context.Background(), m.playerStats, answers)
+	m.playerStats = updatedStats
+	m.lastSummary = summary
+	m.feedback = fmt.Sprintf(i18n.T("tavern_finished_format"), summary.ExpDelta, summary.GoldDelta, summary.Correct)
+	m.showFeedback = true
+	return m
+}
+
 func (m TavernModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
@@ -136,12 +205,41 @@ func (m TavernModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.npcOpening = msg.NPCOpening
 		m.evaluationRubric = msg.EvaluationRubric
 		m.turns = msg.Turns
+		m.live = services.SupportsLiveTavern(m.provider)
 		return m, nil
 
+	case TavernReplyMsg:
+		m.loading = false
+		if msg.Turn != m.currentTurn {
+			return m, nil
+		}
+		m.currentTurn++
+		if msg.Err != nil {
+			// Drop to the scripted lines; the batch evaluation grades every turn at the end.
+			log.Printf("live tavern reply failed: %v", msg.Err)
+			m.live = false
+			if m.currentTurn >= len(m.turns) {
+				m.loading = true
+				return m, m.batchEvaluateCmd()
+			}
+			m.feedback = fmt.Sprintf(i18n.T("tavern_live_unavailable"), msg.Err)
+			m.showFeedback = true
+			return m, nil
+		}
+		m.evaluations = append(m.evaluations, msg.Reply.Evaluation)
+		if m.currentTurn < len(m.turns) {
+			m.turns[m.currentTurn].NPCReply = msg.Reply.NPCReply
+			m.feedback = fmt.Sprintf(i18n.T("tavern_turn_feedback"), msg.Reply.Evaluation.Outcome, msg.Reply.Evaluation.Reason)
+			m.showFeedback = true
+			return m, nil
+		}
+		m.npcClosing = msg.Reply.NPCReply
+		return m.settle(), nil
+
 	case TavernEvalMsg:
 		if msg.Err != nil {
 			m.feedback = i18n.T("tavern_eval_default_fail")
-			m.evaluations = make([]services.TavernEvaluation, 5)
+			m.evaluations = make([]services.TavernEvaluation, len(m.turns))
 			for i := range m.evaluations {
 				m.evaluations[i] = services.TavernEvaluation{
 					Outcome: "normal",
@@ -151,28 +249,8 @@ func (m TavernModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.evaluations = msg.Evaluations
 		}
-
-		outcomes := make([]TavernOutcome, len(m.evaluations))
-		for i, e := range m.evaluations {
-			switch e.Outcome {
-			case "success":
-				outcomes[i] = OutcomeSuccess
-			case "normal":
-				outcomes[i] = OutcomeNormal
-			case "fail":
-				outcomes[i] = OutcomeFail
-			default:
-				outcomes[i] = OutcomeNormal
-			}
-		}
-
-		updatedStats, summary, _ := RunTavernSession(context.Background(), m.playerStats, outcomes)
-		m.playerStats = updatedStats
-		m.lastSummary = toGameSessionSummary(summary)
-		m.feedback = fmt.Sprintf(i18n.T("tavern_finished_format"), summary.ExpDelta, summary.GoldDelta, summary.Correct)
-
-		m.showFeedback = true
-		return m, nil
+		m.loading = false
+		return m.settle(), nil
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -192,9 +270,16 @@ func (m TavernModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+			if m.loading || m.currentTurn >= len(m.turns) {
+				return m, nil
+			}
 			ut := m.input.Value()
 			m.playerUtterances = append(m.playerUtterances, ut)
 			m.input.SetValue("")
+			if m.live {
+				m.loading = true
+				return m, m.liveReplyCmd(ut)
+			}
 			m.currentTurn++
 
 			if m.currentTurn >= len(m.turns) {
@@ -211,21 +296,6 @@ func (m TavernModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func toGameSessionSummary(summary SessionSummary) game.SessionSummary {
-	return game.SessionSummary{
-		Mode:         summary.Mode,
-		Correct:      summary.Correct,
-		ExpDelta:     summary.ExpDelta,
-		HPDelta:      summary.HPDelta,
-		GoldDelta:    summary.GoldDelta,
-		DefenseDelta: summary.DefenseDelta,
-		BestCombo:    summary.BestCombo,
-		Fainted:      summary.Fainted,
-		LeveledUp:    summary.LeveledUp,
-		Note:         summary.Note,
-	}
-}
-
 func (m TavernModel) View() string {
 	if m.quitting {
 		return i18n.T("tavern_exiting") + "\n"
@@ -244,8 +314,15 @@ func (m TavernModel) View() string {
 		}
 		if m.currentTurn < len(m.turns) {
 			content += fmt.Sprintf(i18n.T("tavern_npc_line"), m.npcName, m.turns[m.currentTurn].NPCReply) + "\n\n"
-			content += fmt.Sprintf(i18n.T("tavern_player_turn"), m.currentTurn+1, len(m.turns), m.input.View())
+			if m.loading {
+				content += fmt.Sprintf(i18n.T("tavern_npc_thinking"), m.npcName)
+			} else {
+				content += fmt.Sprintf(i18n.T("tavern_player_turn"), m.currentTurn+1, len(m.turns), m.input.View())
+			}
 		} else {
+			if m.npcClosing != "" {
+				content += fmt.Sprintf(i18n.T("tavern_npc_line"), m.npcName, m.npcClosing) + "\n\n"
+			}
 			content += i18n.T("tavern_evaluations") + "\n"
 			for i, ev := range m.evaluations {
 				content += fmt.Sprintf(i18n.T("tavern_eval_line"), i+1, ev.Outcome, ev.Reason)
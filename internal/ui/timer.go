@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/config"
+	"tui-english-quest/internal/ui/components"
+)
+
+const (
+	questionTickDuration = time.Second
+	// timerWarnAt is when the countdown turns red.
+	timerWarnAt = 5 * time.Second
+)
+
+var (
+	timerStyle     = lipgloss.NewStyle().Foreground(components.ColorInfo)
+	timerWarnStyle = lipgloss.NewStyle().Foreground(components.ColorDanger).Bold(true)
+)
+
+// questionTickMsg advances the countdown of the question identified by id.
+type questionTickMsg struct{ id int }
+
+// QuestionTimer measures how long the player takes on each question and,
+// when a time limit is configured, counts it down once per second.
+type QuestionTimer struct {
+	limit   time.Duration // 0 disables the countdown
+	started time.Time
+	id      int // identifies the running question so stale ticks are dropped
+	running bool
+}
+
+// NewQuestionTimer creates a QuestionTimer using the configured time limit.
+func NewQuestionTimer() QuestionTimer {
+	cfg, _ := config.LoadConfig()
+	return QuestionTimer{limit: time.Duration(cfg.QuestionTimeLimit) * time.Second}
+}
+
+// Start begins timing a new question.
+func (t *QuestionTimer) Start() tea.Cmd {
+	t.id++
+	t.started = time.Now()
+	t.running = true
+	return t.tick()
+}
+
+// Stop ends the current question and returns how long it took. A timed-out
+// question reports the full limit.
+func (t *QuestionTimer) Stop() time.Duration {
+	if !t.running {
+		return 0
+	}
+	t.running = false
+	elapsed := time.Since(t.started)
+	if t.limit > 0 && elapsed > t.limit {
+		elapsed = t.limit
+	}
+	return elapsed
+}
+
+// Tick handles a countdown tick. It reports true once the current question
+// has run out of time; otherwise it schedules the next tick.
+func (t *QuestionTimer) Tick(msg questionTickMsg) (bool, tea.Cmd) {
+	if !t.running || msg.id != t.id {
+		return false, nil
+	}
+	if t.Remaining() <= 0 {
+		return true, nil
+	}
+	return false, t.tick()
+}
+
+// Remaining returns the time left on the current question.
+func (t *QuestionTimer) Remaining() time.Duration {
+	return max(t.limit-time.Since(t.started), 0)
+}
+
+// View renders the countdown, or nothing when no limit is set.
+func (t *QuestionTimer) View() string {
+	if t.limit <= 0 || !t.running {
+		return ""
+	}
+	left := t.Remaining()
+	style := timerStyle
+	if left <= timerWarnAt {
+		style = timerWarnStyle
+	}
+	return style.Render(fmt.Sprintf("⏱ %ds", int(left.Round(time.Second)/time.Second)))
+}
+
+func (t *QuestionTimer) tick() tea.Cmd {
+	if t.limit <= 0 {
+		return nil
+	}
+	id := t.id
+	return tea.Tick(questionTickDuration, func(time.Time) tea.Msg {
+		return questionTickMsg{id: id}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"tui-english-quest/internal/config"
+	"tui-english-quest/internal/db"
 	"tui-english-quest/internal/game"
 	"tui-english-quest/internal/i18n"
 	"tui-english-quest/internal/services"
@@ -36,6 +37,10 @@ const (
 	StateHistory   // History screen
 	StateStatus    // Status screen
 	StateSettings  // Settings screen
+	StateReview    // Review Shrine screen
+	StateEquipment // Equipment screen
+	StateShop      // Shop screen
+	StateBoss      // Boss Lair screen
 )
 
 // Messages for screen transitions
@@ -72,6 +77,10 @@ type RootModel struct {
 	note              string
 	state             AppState
 	confirmingNewGame bool
+	creatingCharacter bool
+	character         CharacterModel
+	pickingProfile    bool
+	profiles          ProfilesModel
 	town              TownModel
 	battle            BattleModel    // Added BattleModel
 	dungeon           DungeonModel   // Added DungeonModel
@@ -83,7 +92,11 @@ type RootModel struct {
 	status            StatusModel
 	settings          SettingsModel
 	result            ResultModel
-	geminiClient      *services.GeminiClient // Add GeminiClient
+	review            ReviewModel
+	equipment         EquipmentModel
+	shop              ShopModel
+	boss              BossModel
+	provider          services.Provider
 	LangPref          string
 	// Terminal dimensions tracked from tea.WindowSizeMsg
 	TermWidth  int
@@ -94,38 +107,55 @@ type RootModel struct {
 func NewRootModel(stats game.Stats, cfg config.Config) RootModel {
 	i18n.SetLang(cfg.LangPref)
 
-	// Initialize Gemini Client
-	gc, err := services.NewGeminiClient(context.Background())
+	provider := newProvider()
+
+	// With several people sharing the machine, ask who is playing first.
+	profiles, err := db.ListProfiles(context.Background())
 	if err != nil {
-		// Handle error, e.g., log it and proceed without Gemini features or exit
-		fmt.Printf("Failed to initialize Gemini Client: %v\n", err)
-		// For now, we'll proceed with a nil client, but a real app might exit or disable features.
+		log.Printf("failed to list profiles: %v", err)
 	}
 
 	return RootModel{
 		Status: stats,
 
-		menu:   []string{i18n.T("menu_start"), i18n.T("menu_new"), i18n.T("menu_quit")},
-		cursor: 0,
-		note:   i18n.T("note_newgame"),
-
-		state:        StateTop,
-		town:         NewTownModel(stats, gc),    // Pass GeminiClient
-		battle:       NewBattleModel(stats, gc),  // Initialize BattleModel
-		dungeon:      NewDungeonModel(stats, gc), // Initialize DungeonModel
-		tavern:       NewTavernModel(stats, gc, cfg.LangPref),
-		spelling:     NewSpellingModel(stats, gc),
-		listening:    NewListeningModel(stats, gc),
-		analysis:     NewAnalysisModel(stats, gc), // Pass GeminiClient
-		history:      NewHistoryModel(stats),
-		status:       NewStatusModel(stats),
-		settings:     NewSettingsModel(stats),
-		result:       NewResultModel(stats, game.SessionSummary{}),
-		geminiClient: gc,
-		LangPref:     cfg.LangPref,
+		menu:           topMenuLabels(),
+		cursor:         0,
+		note:           i18n.T("note_newgame"),
+		pickingProfile: len(profiles) > 1,
+		profiles:       NewProfilesModel(stats),
+
+		state:     StateTop,
+		town:      NewTownModel(stats, provider),
+		battle:    NewBattleModel(stats, provider),  // Initialize BattleModel
+		dungeon:   NewDungeonModel(stats, provider), // Initialize DungeonModel
+		tavern:    NewTavernModel(stats, provider, cfg.LangPref),
+		spelling:  NewSpellingModel(stats, provider),
+		listening: NewListeningModel(stats, provider),
+		analysis:  NewAnalysisModel(stats, provider),
+		history:   NewHistoryModel(stats),
+		status:    NewStatusModel(stats),
+		settings:  NewSettingsModel(stats),
+		result:    NewResultModel(stats, game.SessionSummary{}),
+		provider:  provider,
+		LangPref:  cfg.LangPref,
 	}
 }
 
+func topMenuLabels() []string {
+	return []string{i18n.T("menu_start"), i18n.T("menu_new"), i18n.T("menu_profiles"), i18n.T("menu_quit")}
+}
+
+// newProvider builds the question backend, returning nil when none is usable.
+func newProvider() services.Provider {
+	p, err := services.NewProvider(context.Background())
+	if err != nil {
+		// Proceed without a backend; each mode reports the error when it fetches questions.
+		log.Printf("Failed to initialize question provider: %v", err)
+		return nil
+	}
+	return p
+}
+
 func (m RootModel) Init() tea.Cmd { return nil }
 
 func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -140,12 +170,12 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case AnalysisToTownMsg: // Handle message from AnalysisModel to return to Town
 		m.state = StateTown
-		m.Status = m.analysis.playerStats               // Update RootModel's stats from AnalysisModel
-		m.town = NewTownModel(m.Status, m.geminiClient) // Refresh TownModel with updated stats
-		return m, nil
+		m.Status = m.analysis.playerStats           // Update RootModel's stats from AnalysisModel
+		m.town = NewTownModel(m.Status, m.provider) // Refresh TownModel with updated stats
+		return m, m.town.Init()
 	case TownToAnalysisMsg:
 		m.state = StateAnalysis
-		m.analysis = NewAnalysisModel(m.Status, m.geminiClient)
+		m.analysis = NewAnalysisModel(m.Status, m.provider)
 		return m, m.analysis.Init()
 	case TownToHistoryMsg:
 		m.state = StateHistory
@@ -173,9 +203,12 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// apply new language globally
 			i18n.SetLang(m.LangPref)
 		}
+		// rebuild the backend in case the API key changed
+		m.provider = newProvider()
 		// reinitialize models that depend on language pref
-		m.tavern = NewTavernModel(m.Status, m.geminiClient, m.LangPref)
-		return m, nil
+		m.tavern = NewTavernModel(m.Status, m.provider, m.LangPref)
+		m.town = NewTownModel(m.Status, m.provider)
+		return m, m.town.Init()
 	case SessionResultMsg:
 		m.Status = msg.Stats
 		m.result = NewResultModel(msg.Stats, msg.Summary)
@@ -183,32 +216,78 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.result.Init()
 	case ResultToTownMsg:
 		m.state = StateTown
-		m.town = NewTownModel(m.Status, m.geminiClient)
-		return m, nil
+		m.town = NewTownModel(m.Status, m.provider)
+		return m, m.town.Init()
 	case TownToBattleMsg: // Added TownToBattleMsg handling
 		m.Status = game.FullHeal(m.Status)
 		m.state = StateBattle
-		m.battle = NewBattleModel(m.Status, m.geminiClient) // Initialize BattleModel
+		m.battle = NewBattleModel(m.Status, m.provider) // Initialize BattleModel
 		return m, m.battle.Init()
 	case TownToDungeonMsg: // Added TownToDungeonMsg handling
 		m.Status = game.FullHeal(m.Status)
 		m.state = StateDungeon
-		m.dungeon = NewDungeonModel(m.Status, m.geminiClient) // Initialize DungeonModel
+		m.dungeon = NewDungeonModel(m.Status, m.provider) // Initialize DungeonModel
 		return m, m.dungeon.Init()
 	case TownToTavernMsg:
 		m.Status = game.FullHeal(m.Status)
 		m.state = StateTavern
-		m.tavern = NewTavernModel(m.Status, m.geminiClient, m.LangPref)
+		m.tavern = NewTavernModel(m.Status, m.provider, m.LangPref)
 		return m, m.tavern.Init()
 	case TownToSpellingMsg:
 		m.Status = game.FullHeal(m.Status)
 		m.state = StateSpelling
-		m.spelling = NewSpellingModel(m.Status, m.geminiClient)
+		m.spelling = NewSpellingModel(m.Status, m.provider)
 		return m, m.spelling.Init()
+	case TownToReviewMsg:
+		m.state = StateReview
+		m.review = NewReviewModel(m.Status)
+		return m, m.review.Init()
+	case ReviewToTownMsg:
+		m.state = StateTown
+		return m, nil
+	case TownToEquipmentMsg:
+		m.state = StateEquipment
+		m.equipment = NewEquipmentModel(m.Status)
+		return m, m.equipment.Init()
+	case EquipmentToTownMsg:
+		m.state = StateTown
+		return m, nil
+	case TownToShopMsg:
+		m.state = StateShop
+		m.shop = NewShopModel(m.Status)
+		return m, m.shop.Init()
+	case ShopToTownMsg:
+		m.state = StateTown
+		m.town.playerStats = m.Status
+		return m, nil
+	case TownToBossMsg:
+		m.Status = game.FullHeal(m.Status)
+		m.state = StateBoss
+		m.boss = NewBossModel(m.Status, m.provider)
+		return m, m.boss.Init()
+	case BossToTownMsg:
+		m.state = StateTown
+		m.town = NewTownModel(m.Status, m.provider)
+		return m, m.town.Init()
+	case ReviewStartMsg:
+		return m.startReview(msg.Mode)
+	case ProfileSelectedMsg:
+		return m.switchProfile(msg.ID), nil
+	case ProfileDeletedMsg:
+		if cfg, err := config.LoadConfig(); err == nil {
+			delete(cfg.Profiles, msg.ID)
+			if err := config.SaveConfig(cfg); err != nil {
+				log.Printf("failed to save config: %v", err)
+			}
+		}
+		return m, nil
+	case ProfilesClosedMsg:
+		m.pickingProfile = false
+		return m, nil
 	case TownToListeningMsg:
 		m.Status = game.FullHeal(m.Status)
 		m.state = StateListening
-		m.listening = NewListeningModel(m.Status, m.geminiClient)
+		m.listening = NewListeningModel(m.Status, m.provider)
 		return m, m.listening.Init()
 	}
 
@@ -269,6 +348,24 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.settings = newSettingsModel.(SettingsModel)
 		m.Status = m.settings.playerStats
 		return m, cmd
+	case StateReview:
+		newReviewModel, cmd := m.review.Update(msg)
+		m.review = newReviewModel.(ReviewModel)
+		return m, cmd
+	case StateEquipment:
+		newEquipmentModel, cmd := m.equipment.Update(msg)
+		m.equipment = newEquipmentModel.(EquipmentModel)
+		return m, cmd
+	case StateShop:
+		newShopModel, cmd := m.shop.Update(msg)
+		m.shop = newShopModel.(ShopModel)
+		m.Status = m.shop.playerStats
+		return m, cmd
+	case StateBoss:
+		newBossModel, cmd := m.boss.Update(msg)
+		m.boss = newBossModel.(BossModel)
+		m.Status = m.boss.playerStats
+		return m, cmd
 	default:
 		return m, nil
 	}
@@ -278,10 +375,20 @@ func (m RootModel) updateTop(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		key := msg.String()
-		switch key {
-		case "ctrl+c":
+		if key == "ctrl+c" {
 			return m, tea.Quit
-		case "q": // T034: 途中離脱
+		}
+		if m.creatingCharacter {
+			newCharacterModel, cmd := m.character.Update(msg)
+			m.character = newCharacterModel.(CharacterModel)
+			return m, cmd
+		}
+		if m.pickingProfile {
+			newProfilesModel, cmd := m.profiles.Update(msg)
+			m.profiles = newProfilesModel.(ProfilesModel)
+			return m, cmd
+		}
+		if key == "q" { // T034: 途中離脱
 			if m.Status.HP > 0 {
 				m.note = "Session interrupted. Progress not saved."
 				return m, tea.Quit
@@ -304,6 +411,34 @@ func (m RootModel) updateTop(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleTopEnter()
 		case "n":
 			m = m.requestNewGameConfirmation()
+		case "p":
+			m = m.openProfiles()
+		}
+	case CharacterCreatedMsg:
+		if m.creatingCharacter {
+			m = m.startNewGame(msg.Name, msg.Class)
+			if m.creatingCharacter {
+				return m, nil
+			}
+			return m, m.town.Init()
+		}
+	case CharacterCancelledMsg:
+		if m.creatingCharacter {
+			m.creatingCharacter = false
+			m.note = i18n.T("note_newgame")
+			return m, nil
+		}
+	}
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		if m.creatingCharacter {
+			newCharacterModel, cmd := m.character.Update(msg)
+			m.character = newCharacterModel.(CharacterModel)
+			return m, cmd
+		}
+		if m.pickingProfile {
+			newProfilesModel, cmd := m.profiles.Update(msg)
+			m.profiles = newProfilesModel.(ProfilesModel)
+			return m, cmd
 		}
 	}
 	return m, nil
@@ -313,22 +448,60 @@ func (m RootModel) handleTopEnter() (tea.Model, tea.Cmd) {
 	switch m.cursor {
 	case 0: // Start Adventure
 		m.state = StateTown
-		m.town = NewTownModel(m.Status, m.geminiClient)
-		return m, nil
+		m.town = NewTownModel(m.Status, m.provider)
+		return m, m.town.Init()
 	case 1: // New Game
 		m = m.requestNewGameConfirmation()
 		return m, nil
-	case 2: // Quit
+	case 2: // Switch Profile
+		return m.openProfiles(), nil
+	case 3: // Quit
 		return m, tea.Quit
 	default:
 		return m, nil
 	}
 }
 
+func (m RootModel) openProfiles() RootModel {
+	m.profiles = NewProfilesModel(m.Status)
+	m.pickingProfile = true
+	return m
+}
+
+// switchProfile makes id the active profile: its stats, history and settings
+// replace the current ones and the title menu is shown again.
+func (m RootModel) switchProfile(id string) RootModel {
+	stats, err := game.ActivateProfile(context.Background(), id)
+	if err != nil {
+		m.profiles.note = fmt.Sprintf(i18n.T("profiles_error"), err)
+		return m
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("failed to load config: %v", err)
+	}
+	cfg = cfg.WithProfile(id)
+	if err := config.SaveConfig(cfg); err != nil {
+		log.Printf("failed to save config: %v", err)
+	}
+	m.LangPref = cfg.LangPref
+	i18n.SetLang(m.LangPref)
+
+	m.Status = stats
+	m.pickingProfile = false
+	m.menu = topMenuLabels()
+	m.cursor = 0
+	m.note = fmt.Sprintf(i18n.T("note_profile_switched"), stats.Name)
+	return m
+}
+
 func (m RootModel) handleTopNewGameConfirmationKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch strings.ToLower(msg.String()) {
 	case "y":
-		m = m.startNewGame()
+		m.confirmingNewGame = false
+		m.creatingCharacter = true
+		m.character = NewCharacterModel(m.Status, m.Status.Name)
+		return m, m.character.Init()
 	case "n", "esc":
 		m = m.cancelNewGameConfirmation()
 	}
@@ -353,18 +526,65 @@ func (m RootModel) cancelNewGameConfirmation() RootModel {
 	return m
 }
 
-func (m RootModel) startNewGame() RootModel {
-	m.Status = game.DefaultStats()
-	if err := game.SaveStats(context.Background(), m.Status); err != nil {
-		log.Printf("failed to persist stats after new game: %v", err)
+// startNewGame resets progress and starts over as the character created on
+// the character creation screen.
+func (m RootModel) startNewGame(name, class string) RootModel {
+	stats, err := game.NewCharacter(name, class)
+	if err != nil {
+		m.character.note = fmt.Sprintf(i18n.T("profiles_error"), err)
+		return m
+	}
+	m.Status = stats
+	if err := game.StartOver(context.Background(), m.Status); err != nil {
+		log.Printf("failed to reset profile for new game: %v", err)
 	}
 	m.note = i18n.T("note_newgame")
-	m.town = NewTownModel(m.Status, m.geminiClient)
+	m.town = NewTownModel(m.Status, m.provider)
 	m.state = StateTown
-	m.confirmingNewGame = false
+	m.creatingCharacter = false
 	return m
 }
 
+// startReview serves due review items of mode through the matching mode screen.
+func (m RootModel) startReview(mode string) (tea.Model, tea.Cmd) {
+	cfg, _ := config.LoadConfig()
+	limit := cfg.QuestionsPerSession
+	if limit <= 0 {
+		limit = 5
+	}
+	items, err := game.DueReviews(context.Background(), mode, limit)
+	if err != nil {
+		m.review.note = fmt.Sprintf(i18n.T("review_error"), err)
+		return m, nil
+	}
+
+	switch mode {
+	case services.ModeVocab:
+		if qs := vocabReviewQuestions(items); len(qs) > 0 {
+			m.Status = game.FullHeal(m.Status)
+			m.state = StateBattle
+			m.battle = NewBattleReviewModel(m.Status, qs)
+			return m, m.battle.Init()
+		}
+	case services.ModeGrammar:
+		if traps := grammarReviewTraps(items); len(traps) > 0 {
+			m.Status = game.FullHeal(m.Status)
+			m.state = StateDungeon
+			m.dungeon = NewDungeonReviewModel(m.Status, traps)
+			return m, m.dungeon.Init()
+		}
+	case services.ModeSpelling:
+		if prompts := spellingReviewPrompts(items); len(prompts) > 0 {
+			m.Status = game.FullHeal(m.Status)
+			m.state = StateSpelling
+			m.spelling = NewSpellingReviewModel(m.Status, prompts)
+			return m, m.spelling.Init()
+		}
+	}
+	m.review.note = i18n.T("review_nothing_due")
+	return m, nil
+}
+
 func (m RootModel) centerIfPossible(s string) string {
 
 	if m.TermWidth > 0 && m.TermHeight > 0 {
@@ -400,6 +620,14 @@ func (m RootModel) View() string {
 		out = m.viewStatus()
 	case StateSettings:
 		out = m.viewSettings()
+	case StateReview:
+		out = m.review.View()
+	case StateEquipment:
+		out = m.equipment.View()
+	case StateShop:
+		out = m.shop.View()
+	case StateBoss:
+		out = m.boss.View()
 	default:
 		out = "Unknown state"
 	}
@@ -407,6 +635,12 @@ func (m RootModel) View() string {
 }
 
 func (m RootModel) viewTop() string {
+	if m.creatingCharacter {
+		return m.character.View()
+	}
+	if m.pickingProfile {
+		return m.profiles.View()
+	}
 	// Use shared header and footer components
 	header := components.Header(m.Status, true, 0)
 	body := ""
@@ -477,12 +711,12 @@ func (m RootModel) viewSettings() string {
 
 // runAllModes simulates running all modes sequentially using sample payloads and default answers.
 // This function is now only for testing purposes and will be removed later.
-func runAllModes() (game.Stats, []game.SessionSummary) { // Changed return type to game.SessionSummary
+func runAllModes(p services.Provider) (game.Stats, []game.SessionSummary) { // Changed return type to game.SessionSummary
 	ctx := context.Background()
 	stats := game.DefaultStats()
 	summaries := []game.SessionSummary{} // Changed to game.SessionSummary
 
-	if payload, err := services.FetchAndValidate(ctx, services.ModeVocab); err == nil {
+	if payload, err := services.FetchAndValidate(ctx, p, services.ModeVocab); err == nil {
 		_ = payload
 		ans := make([]game.VocabAnswer, 5) // Changed to game.VocabAnswer
 		for i := range ans {
@@ -495,7 +729,7 @@ func runAllModes() (game.Stats, []game.SessionSummary) { // Changed return type
 		summaries = append(summaries, game.SessionSummary{Mode: services.ModeVocab, Note: err.Error()}) // Changed to game.SessionSummary
 	}
 
-	if payload, err := services.FetchAndValidate(ctx, services.ModeGrammar); err == nil {
+	if payload, err := services.FetchAndValidate(ctx, p, services.ModeGrammar); err == nil {
 		_ = payload
 		ans := make([]game.GrammarAnswer, 5) // Changed to game.GrammarAnswer
 		for i := range ans {
@@ -508,27 +742,27 @@ func runAllModes() (game.Stats, []game.SessionSummary) { // Changed return type
 		summaries = append(summaries, game.SessionSummary{Mode: services.ModeGrammar, Note: err.Error()}) // Changed to game.SessionSummary
 	}
 
-	if payload, err := services.FetchAndValidate(ctx, services.ModeTavern); err == nil {
+	if payload, err := services.FetchAndValidate(ctx, p, services.ModeTavern); err == nil {
 		_ = payload
-		outs := []game.TavernOutcome{game.OutcomeSuccess, game.OutcomeNormal, game.OutcomeSuccess, game.OutcomeFail, game.OutcomeNormal} // Changed to game.TavernOutcome
-		var sum game.SessionSummary                                                                                                      // Changed to game.SessionSummary
-		stats, sum, _ = game.RunTavernSession(ctx, stats, outs)                                                                          // Changed to game.RunTavernSession
+		outs := []game.TavernAnswer{{Outcome: game.OutcomeSuccess}, {Outcome: game.OutcomeNormal}, {Outcome: game.OutcomeSuccess}, {Outcome: game.OutcomeFail}, {Outcome: game.OutcomeNormal}}
+		var sum game.SessionSummary                             // Changed to game.SessionSummary
+		stats, sum, _ = game.RunTavernSession(ctx, stats, outs) // Changed to game.RunTavernSession
 		summaries = append(summaries, sum)
 	} else {
 		summaries = append(summaries, game.SessionSummary{Mode: services.ModeTavern, Note: err.Error()}) // Changed to game.SessionSummary
 	}
 
-	if payload, err := services.FetchAndValidate(ctx, services.ModeSpelling); err == nil {
+	if payload, err := services.FetchAndValidate(ctx, p, services.ModeSpelling); err == nil {
 		_ = payload
-		outs := []game.SpellingOutcome{game.SpellingPerfect, game.SpellingNear, game.SpellingPerfect, game.SpellingFail, game.SpellingNear} // Changed to game.SpellingOutcome
-		var sum game.SessionSummary                                                                                                         // Changed to game.SessionSummary
-		stats, sum, _ = game.RunSpellingSession(ctx, stats, outs)                                                                           // Changed to game.RunSpellingSession
+		outs := []game.SpellingAnswer{{Outcome: game.SpellingPerfect}, {Outcome: game.SpellingNear}, {Outcome: game.SpellingPerfect}, {Outcome: game.SpellingFail}, {Outcome: game.SpellingNear}}
+		var sum game.SessionSummary                               // Changed to game.SessionSummary
+		stats, sum, _ = game.RunSpellingSession(ctx, stats, outs) // Changed to game.RunSpellingSession
 		summaries = append(summaries, sum)
 	} else {
 		summaries = append(summaries, game.SessionSummary{Mode: services.ModeSpelling, Note: err.Error()}) // Changed to game.SessionSummary
 	}
 
-	if payload, err := services.FetchAndValidate(ctx, services.ModeListening); err == nil {
+	if payload, err := services.FetchAndValidate(ctx, p, services.ModeListening); err == nil {
 		_ = payload
 		ans := []game.ListeningAnswer{{Correct: true}, {Correct: true}, {Correct: false}, {Correct: true}, {Correct: true}} // Changed to game.ListeningAnswer
 		var sum game.SessionSummary                                                                                         // Changed to game.SessionSummary
@@ -3,10 +3,12 @@ package ui
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"tui-english-quest/internal/db"
 	"tui-english-quest/internal/game"
 	"tui-english-quest/internal/i18n"
 	"tui-english-quest/internal/services"
@@ -29,7 +31,10 @@ func townMenuLabels() []string {
 		i18n.MenuLabel("town_menu_conversation_tavern"),
 		i18n.MenuLabel("town_menu_spelling_challenge"),
 		i18n.MenuLabel("town_menu_listening_cave"),
+		i18n.MenuLabel("town_menu_boss_lair"),
+		i18n.MenuLabel("town_menu_review_shrine"),
 		i18n.MenuLabel("town_menu_equipment"),
+		i18n.MenuLabel("town_menu_shop"),
 		i18n.MenuLabel("town_menu_ai_analysis"),
 		i18n.MenuLabel("town_menu_history"),
 		i18n.MenuLabel("town_menu_status"),
@@ -43,14 +48,15 @@ type TownModel struct {
 	menuKeys    []string
 	cursor      int
 	aiAdvice    services.WeaknessReport // Placeholder for AI advice
+	provider    services.Provider
 }
 
 // NewTownModel creates a new TownModel.
-func NewTownModel(stats game.Stats, gc *services.GeminiClient) TownModel {
+func NewTownModel(stats game.Stats, p services.Provider) TownModel {
 	// TODO: Fetch actual AI advice based on player history
 	// For now, use a placeholder report.
 	// In a real implementation, playerID would be passed and history fetched.
-	aiReport, err := services.AnalyzeWeakness(context.Background(), gc, stats.Name, stats, 200) // Use player name as ID, limit 200
+	aiReport, err := services.AnalyzeWeakness(context.Background(), p, db.CurrentProfileID(), stats, 200)
 
 	if err != nil {
 		aiReport = services.WeaknessReport{
@@ -65,6 +71,10 @@ func NewTownModel(stats game.Stats, gc *services.GeminiClient) TownModel {
 			"town_menu_conversation_tavern",
 			"town_menu_spelling_challenge",
 			"town_menu_listening_cave",
+			"town_menu_boss_lair",
+			"town_menu_review_shrine",
+			"town_menu_equipment",
+			"town_menu_shop",
 			"town_menu_ai_analysis",
 			"town_menu_history",
 			"town_menu_status",
@@ -72,6 +82,7 @@ func NewTownModel(stats game.Stats, gc *services.GeminiClient) TownModel {
 		},
 		cursor:   0,
 		aiAdvice: aiReport,
+		provider: p,
 	}
 }
 
@@ -82,8 +93,20 @@ type TownToRootMsg struct{}
 
 // TownToDungeonMsg signals to the RootModel to transition to the dungeon screen.
 
+// Init prefetches the next session of every mode in the background so that
+// entering a mode does not wait on the question backend.
 func (m TownModel) Init() tea.Cmd {
-	return nil
+	if m.provider == nil {
+		return nil
+	}
+	p := m.provider
+	return func() tea.Msg {
+		modes := []string{services.ModeVocab, services.ModeGrammar, services.ModeTavern, services.ModeSpelling, services.ModeListening}
+		if err := services.Prefetch(context.Background(), p, modes...); err != nil {
+			log.Printf("question prefetch failed: %v", err)
+		}
+		return nil
+	}
 }
 
 func (m TownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -101,24 +124,32 @@ func (m TownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor++
 			}
 		case "enter":
-			switch m.cursor {
-			case 0:
+			switch m.menuKeys[m.cursor] {
+			case "town_menu_vocab_battle":
 				return m, func() tea.Msg { return TownToBattleMsg{} }
-			case 1:
+			case "town_menu_grammar_dungeon":
 				return m, func() tea.Msg { return TownToDungeonMsg{} }
-			case 2:
+			case "town_menu_conversation_tavern":
 				return m, func() tea.Msg { return TownToTavernMsg{} }
-			case 3:
+			case "town_menu_spelling_challenge":
 				return m, func() tea.Msg { return TownToSpellingMsg{} }
-			case 4:
+			case "town_menu_listening_cave":
 				return m, func() tea.Msg { return TownToListeningMsg{} }
-			case 5:
+			case "town_menu_boss_lair":
+				return m, func() tea.Msg { return TownToBossMsg{} }
+			case "town_menu_review_shrine":
+				return m, func() tea.Msg { return TownToReviewMsg{} }
+			case "town_menu_equipment":
+				return m, func() tea.Msg { return TownToEquipmentMsg{} }
+			case "town_menu_shop":
+				return m, func() tea.Msg { return TownToShopMsg{} }
+			case "town_menu_ai_analysis":
 				return m, func() tea.Msg { return TownToAnalysisMsg{} }
-			case 6:
+			case "town_menu_history":
 				return m, func() tea.Msg { return TownToHistoryMsg{} }
-			case 7:
+			case "town_menu_status":
 				return m, func() tea.Msg { return TownToStatusMsg{} }
-			case 8:
+			case "town_menu_settings":
 				return m, func() tea.Msg { return TownToSettingsMsg{} }
 			default:
 				return m, func() tea.Msg { return TownToRootMsg{} }